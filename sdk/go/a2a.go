@@ -0,0 +1,88 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// a2aProtocolVersion is the A2A protocol version AgentCards are generated for.
+const a2aProtocolVersion = "0.2.5"
+
+// a2aAgentCard is an A2A (Agent-to-Agent) protocol AgentCard.
+type a2aAgentCard struct {
+	ProtocolVersion    string          `json:"protocolVersion"`
+	Name               string          `json:"name"`
+	Description        string          `json:"description"`
+	URL                string          `json:"url"`
+	Version            string          `json:"version"`
+	Capabilities       a2aCapabilities `json:"capabilities"`
+	DefaultInputModes  []string        `json:"defaultInputModes"`
+	DefaultOutputModes []string        `json:"defaultOutputModes"`
+	Skills             []a2aSkill      `json:"skills"`
+}
+
+type a2aCapabilities struct {
+	Streaming         bool `json:"streaming"`
+	PushNotifications bool `json:"pushNotifications"`
+}
+
+type a2aSkill struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags"`
+}
+
+// ToA2AAgentCard renders the agent as an A2A protocol AgentCard in JSON.
+// The agent's endpoint is baseURL followed by its qualified name. Each of
+// the agent's Skills becomes an A2A skill tagged "skill", and each tool in
+// AllTools becomes an A2A skill tagged "tool", with the custom tool's
+// description or the canonical one from ToolDescriptions. An unset Version
+// is reported as "0.0.0".
+func (a *Agent) ToA2AAgentCard(baseURL string) ([]byte, error) {
+	if a.Name == "" {
+		return nil, fmt.Errorf("a2a agent card: agent name is required")
+	}
+	base, err := url.Parse(baseURL)
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("a2a agent card: invalid base URL %q", baseURL)
+	}
+
+	version := a.Version
+	if version == "" {
+		version = "0.0.0"
+	}
+
+	tools := a.AllTools()
+	skills := make([]a2aSkill, 0, len(a.Skills)+len(tools))
+	for _, skill := range a.Skills {
+		skills = append(skills, a2aSkill{ID: skill, Name: skill, Tags: []string{"skill"}})
+	}
+	for _, tool := range tools {
+		skills = append(skills, a2aSkill{
+			ID:          "tool:" + tool,
+			Name:        tool,
+			Description: a.toolDescription(tool),
+			Tags:        []string{"tool"},
+		})
+	}
+
+	card := a2aAgentCard{
+		ProtocolVersion:    a2aProtocolVersion,
+		Name:               a.QualifiedName(),
+		Description:        a.Description,
+		URL:                strings.TrimSuffix(base.String(), "/") + "/" + a.QualifiedName(),
+		Version:            version,
+		DefaultInputModes:  []string{"text/plain"},
+		DefaultOutputModes: []string{"text/plain"},
+		Skills:             skills,
+	}
+
+	data, err := json.MarshalIndent(card, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("a2a agent card: %w", err)
+	}
+	return data, nil
+}
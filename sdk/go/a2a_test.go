@@ -0,0 +1,64 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAgentToA2AAgentCard(t *testing.T) {
+	agent := NewAgent("reviewer", "Reviews pull requests.").
+		WithNamespace("shared").
+		WithTools("Read", "CustomLookup")
+	agent.Skills = []string{"code-review"}
+	agent.Version = "1.4.0"
+
+	data, err := agent.ToA2AAgentCard("https://agents.example.com/a2a/")
+	if err != nil {
+		t.Fatalf("ToA2AAgentCard failed: %v", err)
+	}
+
+	var card a2aAgentCard
+	if err := json.Unmarshal(data, &card); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, data)
+	}
+
+	if card.Name != "shared/reviewer" || card.Version != "1.4.0" {
+		t.Errorf("card = %+v", card)
+	}
+	if card.URL != "https://agents.example.com/a2a/shared/reviewer" {
+		t.Errorf("URL = %q", card.URL)
+	}
+	if len(card.Skills) != 3 {
+		t.Fatalf("len(Skills) = %d, want 3", len(card.Skills))
+	}
+	if card.Skills[0].ID != "code-review" || card.Skills[0].Tags[0] != "skill" {
+		t.Errorf("Skills[0] = %+v", card.Skills[0])
+	}
+	if card.Skills[1].ID != "tool:Read" || card.Skills[1].Description != ToolDescriptions[ToolRead] {
+		t.Errorf("Skills[1] = %+v", card.Skills[1])
+	}
+	if card.Skills[2].Description != "" {
+		t.Errorf("non-canonical tool should have no description, got %q", card.Skills[2].Description)
+	}
+}
+
+func TestAgentToA2AAgentCardErrors(t *testing.T) {
+	if _, err := NewAgent("a", "").ToA2AAgentCard("agents.example.com"); err == nil {
+		t.Error("expected error for base URL without scheme")
+	}
+	if _, err := (&Agent{}).ToA2AAgentCard("https://agents.example.com"); err == nil {
+		t.Error("expected error for agent without name")
+	}
+
+	data, err := NewAgent("a", "").ToA2AAgentCard("https://agents.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var card a2aAgentCard
+	if err := json.Unmarshal(data, &card); err != nil {
+		t.Fatal(err)
+	}
+	if card.Version != "0.0.0" || card.Skills == nil {
+		t.Errorf("card = %+v", card)
+	}
+}
@@ -14,6 +14,14 @@
 //	data, _ := json.MarshalIndent(agent, "", "  ")
 package multiagentspec
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
 // Model represents the model capability tier.
 type Model string
 
@@ -23,6 +31,19 @@ const (
 	ModelOpus   Model = "opus"
 )
 
+// models lists every canonical Model tier.
+var models = []Model{ModelHaiku, ModelSonnet, ModelOpus}
+
+// Valid reports whether m is a canonical model tier.
+func (m Model) Valid() bool {
+	for _, known := range models {
+		if m == known {
+			return true
+		}
+	}
+	return false
+}
+
 // Tool represents canonical tool names available to agents.
 type Tool string
 
@@ -38,6 +59,39 @@ const (
 	ToolTask      Tool = "Task"
 )
 
+// canonicalTools lists every canonical Tool.
+var canonicalTools = []Tool{
+	ToolWebSearch, ToolWebFetch, ToolRead, ToolWrite, ToolGlob,
+	ToolGrep, ToolBash, ToolEdit, ToolTask,
+}
+
+// ToolDescriptions maps canonical tools to short human-readable descriptions.
+var ToolDescriptions = map[Tool]string{
+	ToolWebSearch: "Search the web",
+	ToolWebFetch:  "Fetch the contents of a URL",
+	ToolRead:      "Read file contents",
+	ToolWrite:     "Create or overwrite files",
+	ToolGlob:      "Find files by glob pattern",
+	ToolGrep:      "Search file contents with regular expressions",
+	ToolBash:      "Run shell commands",
+	ToolEdit:      "Make targeted edits to existing files",
+	ToolTask:      "Delegate work to a sub-agent",
+}
+
+// CustomTool describes a tool outside the canonical set, such as an internal
+// or proprietary tool. Platform mappings pass custom tool names through
+// unchanged.
+type CustomTool struct {
+	// Name is the tool name as exposed to the agent.
+	Name string `json:"name" yaml:"name"`
+
+	// Description describes what the tool does.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	// Schema is a JSON Schema for the tool's input.
+	Schema json.RawMessage `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
 // TaskType represents how a task is executed.
 type TaskType string
 
@@ -48,6 +102,75 @@ const (
 	TaskTypeManual  TaskType = "manual"
 )
 
+// MemoryType represents how long an agent's memory persists.
+type MemoryType string
+
+const (
+	MemoryEphemeral  MemoryType = "ephemeral"
+	MemoryPersistent MemoryType = "persistent"
+)
+
+// MemoryConfig configures state retained by a stateful agent.
+type MemoryConfig struct {
+	// Type is the memory lifetime (ephemeral, persistent).
+	Type MemoryType `json:"type" yaml:"type"`
+
+	// Backend is the storage backend (e.g., redis, dynamodb, pvc).
+	// Required for persistent memory.
+	Backend string `json:"backend,omitempty" yaml:"backend,omitempty"`
+
+	// TTLSeconds is how long stored state is retained. Zero means no expiry.
+	TTLSeconds int `json:"ttlSeconds,omitempty" yaml:"ttlSeconds,omitempty"`
+}
+
+// Validate checks that the memory configuration is well-formed.
+func (m *MemoryConfig) Validate() error {
+	var errs ValidationErrors
+	switch m.Type {
+	case MemoryEphemeral:
+	case MemoryPersistent:
+		if m.Backend == "" {
+			errs.addf("backend", "is required for persistent memory")
+		}
+	case "":
+		errs.addf("type", "is required")
+	default:
+		errs.addf("type", "unknown memory type %q (allowed: %s, %s)", m.Type, MemoryEphemeral, MemoryPersistent)
+	}
+	if m.TTLSeconds < 0 {
+		errs.addf("ttlSeconds", "must be non-negative, got %d", m.TTLSeconds)
+	}
+	return errs.err()
+}
+
+// RateLimitConfig caps how heavily an agent may call its model. Zero values
+// mean no limit.
+type RateLimitConfig struct {
+	// RequestsPerMinute is the maximum number of model requests per minute.
+	RequestsPerMinute int `json:"requestsPerMinute,omitempty" yaml:"requestsPerMinute,omitempty"`
+
+	// TokensPerMinute is the maximum number of tokens consumed per minute.
+	TokensPerMinute int `json:"tokensPerMinute,omitempty" yaml:"tokensPerMinute,omitempty"`
+
+	// Concurrency is the maximum number of in-flight requests.
+	Concurrency int `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
+}
+
+// Validate checks that no limit is negative.
+func (r *RateLimitConfig) Validate() error {
+	var errs ValidationErrors
+	if r.RequestsPerMinute < 0 {
+		errs.addf("requestsPerMinute", "must be non-negative, got %d", r.RequestsPerMinute)
+	}
+	if r.TokensPerMinute < 0 {
+		errs.addf("tokensPerMinute", "must be non-negative, got %d", r.TokensPerMinute)
+	}
+	if r.Concurrency < 0 {
+		errs.addf("concurrency", "must be non-negative, got %d", r.Concurrency)
+	}
+	return errs.err()
+}
+
 // Task represents a task that an agent can perform.
 type Task struct {
 	// ID is the unique task identifier within this agent.
@@ -79,6 +202,10 @@ type Task struct {
 
 	// HumanInLoop describes when to prompt for human intervention.
 	HumanInLoop string `json:"human_in_loop,omitempty"`
+
+	// TimeoutSeconds bounds how long the task may run. Zero means the
+	// runner's default applies.
+	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
 }
 
 // Agent represents an agent definition.
@@ -93,6 +220,9 @@ type Agent struct {
 	// Description is a brief summary of what the agent does.
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 
+	// Version is the semantic version of the agent definition (e.g., 1.2.0).
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+
 	// Icon is the icon identifier for visual representation.
 	// Formats: 'brandkit:name' (from brandkit repo), 'lucide:name' (Lucide icon),
 	// or plain name for inference.
@@ -101,26 +231,122 @@ type Agent struct {
 	// Model is the capability tier (haiku, sonnet, opus).
 	Model Model `json:"model,omitempty" yaml:"model,omitempty"`
 
+	// ModelFallback lists models to try, in order, when Model is unavailable.
+	ModelFallback []Model `json:"modelFallback,omitempty" yaml:"modelFallback,omitempty"`
+
+	// ModelByPlatform overrides Model on specific deployment platforms
+	// (e.g., opus on aws-agentcore but sonnet on claude-code).
+	ModelByPlatform map[Platform]Model `json:"modelByPlatform,omitempty" yaml:"modelByPlatform,omitempty"`
+
 	// Tools are the tools available to this agent.
 	Tools []string `json:"tools,omitempty" yaml:"tools,omitempty"`
 
+	// CustomTools are tools outside the canonical set available to this agent.
+	CustomTools []CustomTool `json:"customTools,omitempty" yaml:"customTools,omitempty"`
+
 	// AllowedTools are tools that can execute without user confirmation.
 	AllowedTools []string `json:"allowedTools,omitempty" yaml:"allowedTools,omitempty"`
 
 	// Skills are capabilities the agent can invoke.
 	Skills []string `json:"skills,omitempty" yaml:"skills,omitempty"`
 
-	// Dependencies are other agents this agent depends on.
+	// Dependencies are other agents this agent depends on. Entries may carry
+	// a version constraint (e.g., research@>=1.2.0); see DependencyConstraints.
 	Dependencies []string `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
 
 	// Requires lists external tools or binaries required (e.g., go, git).
 	Requires []string `json:"requires,omitempty" yaml:"requires,omitempty"`
 
+	// Env holds environment variables set when the agent runs.
+	Env map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
+
 	// Instructions is the system prompt for the agent.
 	Instructions string `json:"instructions,omitempty" yaml:"instructions,omitempty"`
 
 	// Tasks are the tasks this agent can perform.
 	Tasks []Task `json:"tasks,omitempty" yaml:"tasks,omitempty"`
+
+	// Memory configures state retained across invocations for stateful agents.
+	Memory *MemoryConfig `json:"memory,omitempty" yaml:"memory,omitempty"`
+
+	// RateLimit caps the agent's model usage for cost control.
+	RateLimit *RateLimitConfig `json:"rateLimit,omitempty" yaml:"rateLimit,omitempty"`
+
+	// OutputSchema is a JSON Schema for the structured output the agent
+	// produces; see ValidateOutput. In markdown frontmatter it is written as
+	// YAML and converted to JSON by ParseAgentMarkdown.
+	OutputSchema json.RawMessage `json:"outputSchema,omitempty" yaml:"-"`
+}
+
+// DeprecatedAgentFields maps legacy Agent JSON keys to their current names.
+// Legacy keys are still accepted when decoding, with a deprecation warning.
+var DeprecatedAgentFields = map[string]string{
+	"prompt": "instructions",
+}
+
+var (
+	deprecationsMu   sync.Mutex
+	lastDeprecations []string
+)
+
+// LastDeprecations returns the deprecation warnings recorded by the most
+// recent Agent JSON decode. It is shared across goroutines, so concurrent
+// decoders should not rely on it to attribute warnings to a specific agent.
+func LastDeprecations() []string {
+	deprecationsMu.Lock()
+	defer deprecationsMu.Unlock()
+	return append([]string(nil), lastDeprecations...)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Legacy keys listed in
+// DeprecatedAgentFields are migrated to their current names (the current key
+// wins if both are present) and a warning is recorded for LastDeprecations.
+func (a *Agent) UnmarshalJSON(data []byte) error {
+	type agentJSON Agent
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	legacyKeys := make([]string, 0, len(DeprecatedAgentFields))
+	for legacy := range DeprecatedAgentFields {
+		legacyKeys = append(legacyKeys, legacy)
+	}
+	sort.Strings(legacyKeys)
+
+	var warnings []string
+	for _, legacy := range legacyKeys {
+		value, ok := raw[legacy]
+		if !ok {
+			continue
+		}
+		current := DeprecatedAgentFields[legacy]
+		warnings = append(warnings, fmt.Sprintf("agent field %q is deprecated, use %q", legacy, current))
+		if _, exists := raw[current]; !exists {
+			raw[current] = value
+		}
+		delete(raw, legacy)
+	}
+
+	if len(warnings) > 0 {
+		migrated, err := json.Marshal(raw)
+		if err != nil {
+			return err
+		}
+		data = migrated
+	}
+
+	var decoded agentJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*a = Agent(decoded)
+
+	deprecationsMu.Lock()
+	lastDeprecations = warnings
+	deprecationsMu.Unlock()
+	return nil
 }
 
 // NewAgent creates a new Agent with the given name and description.
@@ -156,6 +382,193 @@ func (a *Agent) WithNamespace(namespace string) *Agent {
 	return a
 }
 
+// Validate checks that the agent definition is well-formed.
+// It returns ValidationErrors describing every problem found.
+func (a *Agent) Validate() error {
+	var errs ValidationErrors
+	if a.Name == "" {
+		errs.addf("name", "is required")
+	}
+	if a.Version != "" {
+		if _, err := parseSemver(a.Version); err != nil {
+			errs.add("version", err)
+		}
+	}
+	for i, dep := range a.Dependencies {
+		if _, err := ParseDepConstraint(dep); err != nil {
+			errs.add(fmt.Sprintf("dependencies[%d]", i), err)
+		} else if name := dependencyName(dep); a.Name != "" && (name == a.Name || name == a.QualifiedName()) {
+			errs.addf(fmt.Sprintf("dependencies[%d]", i), "agent %s cannot depend on itself", a.QualifiedName())
+		}
+	}
+	for i, m := range a.ModelFallback {
+		if !m.Valid() {
+			errs.addf(fmt.Sprintf("modelFallback[%d]", i), "unknown model %q", m)
+		}
+	}
+	platforms := make([]string, 0, len(a.ModelByPlatform))
+	for p := range a.ModelByPlatform {
+		platforms = append(platforms, string(p))
+	}
+	sort.Strings(platforms)
+	for _, p := range platforms {
+		path := fmt.Sprintf("modelByPlatform[%s]", p)
+		if !Platform(p).Valid() {
+			errs.addf(path, "unknown platform %q", p)
+		}
+		if m := a.ModelByPlatform[Platform(p)]; !m.Valid() {
+			errs.addf(path, "unknown model %q", m)
+		}
+	}
+	seen := make(map[string]bool, len(a.CustomTools))
+	for i, ct := range a.CustomTools {
+		path := fmt.Sprintf("customTools[%d]", i)
+		switch _, canonical := CanonicalTool(ct.Name); {
+		case ct.Name == "":
+			errs.addf(path+".name", "is required")
+		case canonical:
+			errs.addf(path+".name", "%q collides with a canonical tool", ct.Name)
+		case seen[ct.Name]:
+			errs.addf(path+".name", "duplicate custom tool %q", ct.Name)
+		}
+		seen[ct.Name] = true
+		if len(ct.Schema) > 0 {
+			errs.add(path+".schema", validateSchemaSyntax(ct.Schema))
+		}
+	}
+	for i, task := range a.Tasks {
+		if task.TimeoutSeconds < 0 {
+			errs.addf(fmt.Sprintf("tasks[%d].timeout_seconds", i), "must be non-negative, got %d", task.TimeoutSeconds)
+		}
+	}
+	if a.Memory != nil {
+		errs.add("memory", a.Memory.Validate())
+	}
+	if a.RateLimit != nil {
+		errs.add("rateLimit", a.RateLimit.Validate())
+	}
+	if len(a.OutputSchema) > 0 {
+		errs.add("outputSchema", validateSchemaSyntax(a.OutputSchema))
+	}
+	return errs.err()
+}
+
+// ValidateOutput checks that data, a result produced by the agent, is JSON
+// that conforms to OutputSchema. Agents without an OutputSchema accept any
+// output. Violations are returned as ValidationErrors with JSON Pointer paths
+// into data (e.g., "#/items/0"); see validateInstance for the supported
+// keywords.
+func (a *Agent) ValidateOutput(data []byte) error {
+	if len(a.OutputSchema) == 0 {
+		return nil
+	}
+	var schema, value interface{}
+	if err := json.Unmarshal(a.OutputSchema, &schema); err != nil {
+		return fmt.Errorf("agent %s: parse output schema: %w", a.Name, err)
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("agent %s: parse output: %w", a.Name, err)
+	}
+	var errs ValidationErrors
+	validateInstance(schema, value, "#", &errs)
+	return errs.err()
+}
+
+// AllTools returns the agent's Tools followed by the names of its
+// CustomTools, without duplicates.
+func (a *Agent) AllTools() []string {
+	seen := make(map[string]bool, len(a.Tools)+len(a.CustomTools))
+	all := make([]string, 0, len(a.Tools)+len(a.CustomTools))
+	for _, name := range a.Tools {
+		if !seen[name] {
+			seen[name] = true
+			all = append(all, name)
+		}
+	}
+	for _, ct := range a.CustomTools {
+		if !seen[ct.Name] {
+			seen[ct.Name] = true
+			all = append(all, ct.Name)
+		}
+	}
+	return all
+}
+
+// toolDescription returns the description of a custom or canonical tool.
+func (a *Agent) toolDescription(name string) string {
+	for _, ct := range a.CustomTools {
+		if ct.Name == name {
+			return ct.Description
+		}
+	}
+	return ToolDescriptions[Tool(name)]
+}
+
+// Build validates the agent and returns it, or returns an error if it is
+// invalid. It is intended as the final call of a builder chain:
+//
+//	agent, err := NewAgent("reviewer", "Reviews code").WithModel(ModelOpus).Build()
+func (a *Agent) Build() (*Agent, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// ResolveModel returns the first model in the agent's Model followed by
+// ModelFallback that is marked available. An unset Model counts as
+// ModelSonnet. It returns an error if none of the candidates are available.
+func (a *Agent) ResolveModel(available map[Model]bool) (Model, error) {
+	candidates := append([]Model{a.effectiveModel()}, a.ModelFallback...)
+	for _, m := range candidates {
+		if available[m] {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("agent %s: none of models %v are available", a.Name, candidates)
+}
+
+// ModelFor returns the model the agent uses on platform p: its
+// ModelByPlatform override if set, otherwise Model, defaulting to ModelSonnet.
+func (a *Agent) ModelFor(p Platform) Model {
+	if m, ok := a.ModelByPlatform[p]; ok && m != "" {
+		return m
+	}
+	return a.effectiveModel()
+}
+
+// Equal reports whether a and b define the same agent. Fields are compared by
+// their JSON encoding, so nil and empty lists are equal, and an unset Model is
+// treated as ModelSonnet.
+func (a *Agent) Equal(b *Agent) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	x, y := *a, *b
+	x.Model, y.Model = a.effectiveModel(), b.effectiveModel()
+	dx, errX := json.Marshal(x)
+	dy, errY := json.Marshal(y)
+	return errX == nil && errY == nil && bytes.Equal(dx, dy)
+}
+
+// hasTool reports whether the agent is granted the given tool.
+func (a *Agent) hasTool(tool Tool) bool {
+	for _, t := range a.Tools {
+		if t == string(tool) {
+			return true
+		}
+	}
+	return false
+}
+
+// effectiveModel returns the agent's model, defaulting to ModelSonnet when unset.
+func (a *Agent) effectiveModel() Model {
+	if a.Model == "" {
+		return ModelSonnet
+	}
+	return a.Model
+}
+
 // QualifiedName returns the fully qualified agent name.
 // Returns "namespace/name" if namespace is set, otherwise just "name".
 func (a *Agent) QualifiedName() string {
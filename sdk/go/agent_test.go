@@ -2,6 +2,7 @@ package multiagentspec
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 )
 
@@ -163,3 +164,270 @@ func TestAgentJSONOmitEmpty(t *testing.T) {
 		t.Error("model should be omitted when empty")
 	}
 }
+
+func TestMemoryConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		memory  MemoryConfig
+		wantErr bool
+	}{
+		{"ephemeral", MemoryConfig{Type: MemoryEphemeral}, false},
+		{"persistent with backend", MemoryConfig{Type: MemoryPersistent, Backend: "redis", TTLSeconds: 3600}, false},
+		{"persistent without backend", MemoryConfig{Type: MemoryPersistent}, true},
+		{"missing type", MemoryConfig{}, true},
+		{"unknown type", MemoryConfig{Type: "forever"}, true},
+		{"negative ttl", MemoryConfig{Type: MemoryEphemeral, TTLSeconds: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.memory.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAgentValidate(t *testing.T) {
+	agent := NewAgent("stateful", "")
+	if err := agent.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+
+	agent.Memory = &MemoryConfig{Type: MemoryPersistent}
+	err := agent.Validate()
+	if err == nil {
+		t.Fatal("Validate() should fail for persistent memory without backend")
+	}
+	if err.Error() != "memory.backend: is required for persistent memory" {
+		t.Errorf("Validate() = %q", err)
+	}
+
+	if err := (&Agent{}).Validate(); err == nil {
+		t.Error("Validate() should fail for empty name")
+	}
+}
+
+func TestAgentAllTools(t *testing.T) {
+	agent := NewAgent("a", "").WithTools("Read", "Grep", "Read")
+	agent.CustomTools = []CustomTool{
+		{Name: "jira_lookup", Description: "Look up a Jira issue"},
+		{Name: "Grep"},
+	}
+	if got := strings.Join(agent.AllTools(), ","); got != "Read,Grep,jira_lookup" {
+		t.Errorf("AllTools() = %s", got)
+	}
+	if got := MapToolToKiroCLI(Tool("jira_lookup")); got != "jira_lookup" {
+		t.Errorf("custom tool should pass through mapping, got %q", got)
+	}
+}
+
+func TestAgentValidateCustomTools(t *testing.T) {
+	agent := NewAgent("a", "")
+	agent.CustomTools = []CustomTool{
+		{Name: "jira_lookup", Schema: json.RawMessage(`{"type":"object"}`)},
+		{Name: "jira_lookup"},
+		{Name: "web_search"},
+		{Schema: json.RawMessage(`{"type":"nope"}`)},
+	}
+
+	err := agent.Validate()
+	if err == nil {
+		t.Fatal("Validate() = nil, want error")
+	}
+	for _, want := range []string{
+		`customTools[1].name: duplicate custom tool "jira_lookup"`,
+		`customTools[2].name: "web_search" collides with a canonical tool`,
+		"customTools[3].name: is required",
+		"customTools[3].schema: ",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %q, want it to contain %q", err, want)
+		}
+	}
+}
+
+func TestAgentBuild(t *testing.T) {
+	agent, err := NewAgent("reviewer", "").WithModel(ModelOpus).Build()
+	if err != nil || agent == nil || agent.Model != ModelOpus {
+		t.Fatalf("Build() = %v, %v", agent, err)
+	}
+	if agent, err := NewAgent("", "").Build(); err == nil || agent != nil {
+		t.Errorf("Build() = %v, %v; want error", agent, err)
+	}
+}
+
+func TestAgentResolveModel(t *testing.T) {
+	agent := NewAgent("a", "").WithModel(ModelOpus)
+	agent.ModelFallback = []Model{ModelSonnet, ModelHaiku}
+
+	got, err := agent.ResolveModel(map[Model]bool{ModelOpus: true, ModelHaiku: true})
+	if err != nil || got != ModelOpus {
+		t.Errorf("ResolveModel() = %q, %v; want opus", got, err)
+	}
+
+	got, err = agent.ResolveModel(map[Model]bool{ModelHaiku: true})
+	if err != nil || got != ModelHaiku {
+		t.Errorf("ResolveModel() = %q, %v; want haiku", got, err)
+	}
+
+	if _, err := agent.ResolveModel(nil); err == nil {
+		t.Error("ResolveModel() should fail when no model is available")
+	}
+
+	// Unset Model falls back to the default tier before ModelFallback.
+	unset := &Agent{Name: "b", ModelFallback: []Model{ModelHaiku}}
+	got, err = unset.ResolveModel(map[Model]bool{ModelSonnet: true, ModelHaiku: true})
+	if err != nil || got != ModelSonnet {
+		t.Errorf("ResolveModel() = %q, %v; want sonnet", got, err)
+	}
+}
+
+func TestAgentValidateModelFallback(t *testing.T) {
+	agent := NewAgent("a", "")
+	agent.ModelFallback = []Model{ModelHaiku, "gpt-4"}
+	err := agent.Validate()
+	if err == nil {
+		t.Fatal("Validate() should fail for unknown fallback model")
+	}
+	if err.Error() != `modelFallback[1]: unknown model "gpt-4"` {
+		t.Errorf("Validate() = %q", err)
+	}
+}
+
+func TestAgentModelFor(t *testing.T) {
+	agent := NewAgent("a", "")
+	agent.ModelByPlatform = map[Platform]Model{PlatformAWSAgentCore: ModelOpus}
+	if got := agent.ModelFor(PlatformAWSAgentCore); got != ModelOpus {
+		t.Errorf("ModelFor(aws-agentcore) = %q, want opus", got)
+	}
+	if got := agent.ModelFor(PlatformClaudeCode); got != ModelSonnet {
+		t.Errorf("ModelFor(claude-code) = %q, want sonnet", got)
+	}
+
+	agent.ModelByPlatform = map[Platform]Model{PlatformClaudeCode: "gpt-4", "mainframe": ModelHaiku}
+	err := agent.Validate()
+	want := `modelByPlatform[claude-code]: unknown model "gpt-4"; modelByPlatform[mainframe]: unknown platform "mainframe"`
+	if err == nil || err.Error() != want {
+		t.Errorf("Validate() = %v, want %q", err, want)
+	}
+}
+
+func TestAgentValidateSelfDependency(t *testing.T) {
+	agent := NewAgent("lead", "").WithNamespace("prd")
+	agent.Dependencies = []string{"research", "prd/lead@>=1.0.0"}
+	err := agent.Validate()
+	if err == nil {
+		t.Fatal("Validate() should fail for self-dependency")
+	}
+	if err.Error() != "dependencies[1]: agent prd/lead cannot depend on itself" {
+		t.Errorf("Validate() = %q", err)
+	}
+
+	// Agents are also matched by bare name, so that refers to this one too.
+	agent.Dependencies = []string{"lead"}
+	if err := agent.Validate(); err == nil || err.Error() != "dependencies[0]: agent prd/lead cannot depend on itself" {
+		t.Errorf("Validate() = %v, want self-dependency error for the bare name", err)
+	}
+}
+
+func TestAgentValidateRateLimit(t *testing.T) {
+	agent := NewAgent("a", "")
+	agent.RateLimit = &RateLimitConfig{RequestsPerMinute: 60, TokensPerMinute: -1, Concurrency: -2}
+	err := agent.Validate()
+	if err == nil {
+		t.Fatal("Validate() should fail for negative rate limits")
+	}
+	want := "rateLimit.tokensPerMinute: must be non-negative, got -1; rateLimit.concurrency: must be non-negative, got -2"
+	if err.Error() != want {
+		t.Errorf("Validate() = %q, want %q", err, want)
+	}
+
+	agent.RateLimit = &RateLimitConfig{RequestsPerMinute: 60}
+	if err := agent.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+}
+
+func TestAgentEqual(t *testing.T) {
+	a := NewAgent("a", "desc").WithTools("Read")
+	b := &Agent{Name: "a", Description: "desc", Tools: []string{"Read"}, Skills: []string{}}
+	if !a.Equal(b) {
+		t.Error("Equal() = false for agents differing only in defaults")
+	}
+	b.Tools = []string{"Write"}
+	if a.Equal(b) {
+		t.Error("Equal() = true for agents with different tools")
+	}
+	if a.Equal(nil) || !(*Agent)(nil).Equal(nil) {
+		t.Error("Equal() mishandles nil agents")
+	}
+}
+
+func TestAgentMemorySerialization(t *testing.T) {
+	agent := NewAgent("stateful", "")
+	data, err := json.Marshal(agent)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	if strings.Contains(string(data), "memory") {
+		t.Errorf("memory should be omitted when nil: %s", data)
+	}
+
+	agent.Memory = &MemoryConfig{Type: MemoryPersistent, Backend: "dynamodb", TTLSeconds: 60}
+	data, err = json.Marshal(agent)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+
+	var decoded Agent
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if decoded.Memory == nil || *decoded.Memory != *agent.Memory {
+		t.Errorf("Memory = %+v, want %+v", decoded.Memory, agent.Memory)
+	}
+}
+
+func TestAgentUnmarshalJSONDeprecatedFields(t *testing.T) {
+	var agent Agent
+	if err := json.Unmarshal([]byte(`{"name": "legacy", "prompt": "Do the thing."}`), &agent); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if agent.Instructions != "Do the thing." {
+		t.Errorf("Instructions = %q, want migrated prompt", agent.Instructions)
+	}
+
+	deps := LastDeprecations()
+	if len(deps) != 1 || !strings.Contains(deps[0], `"prompt" is deprecated, use "instructions"`) {
+		t.Errorf("LastDeprecations() = %v", deps)
+	}
+
+	// The current key wins when both are present.
+	agent = Agent{}
+	if err := json.Unmarshal([]byte(`{"name": "both", "prompt": "old", "instructions": "new"}`), &agent); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if agent.Instructions != "new" {
+		t.Errorf("Instructions = %q, want %q", agent.Instructions, "new")
+	}
+
+	// A clean decode clears previous warnings.
+	if err := json.Unmarshal([]byte(`{"name": "modern", "instructions": "x"}`), &agent); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if deps := LastDeprecations(); len(deps) != 0 {
+		t.Errorf("LastDeprecations() = %v, want none", deps)
+	}
+}
+
+func TestAgentUnmarshalJSONInvalid(t *testing.T) {
+	var agent Agent
+	if err := json.Unmarshal([]byte(`{"name": 5}`), &agent); err == nil {
+		t.Error("json.Unmarshal should fail for invalid name type")
+	}
+	if err := json.Unmarshal([]byte(`[]`), &agent); err == nil {
+		t.Error("json.Unmarshal should fail for non-object")
+	}
+}
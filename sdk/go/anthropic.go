@@ -0,0 +1,76 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ToolInputSchemas maps canonical tools to the JSON Schema of their input,
+// as used in Anthropic Messages API tool definitions.
+var ToolInputSchemas = map[Tool]json.RawMessage{
+	ToolWebSearch: json.RawMessage(`{"type":"object","properties":{"query":{"type":"string","description":"The search query"}},"required":["query"]}`),
+	ToolWebFetch:  json.RawMessage(`{"type":"object","properties":{"url":{"type":"string","format":"uri","description":"The URL to fetch"}},"required":["url"]}`),
+	ToolRead:      json.RawMessage(`{"type":"object","properties":{"file_path":{"type":"string","description":"Path of the file to read"},"offset":{"type":"integer","description":"Line to start reading from"},"limit":{"type":"integer","description":"Number of lines to read"}},"required":["file_path"]}`),
+	ToolWrite:     json.RawMessage(`{"type":"object","properties":{"file_path":{"type":"string","description":"Path of the file to write"},"content":{"type":"string","description":"Content to write"}},"required":["file_path","content"]}`),
+	ToolGlob:      json.RawMessage(`{"type":"object","properties":{"pattern":{"type":"string","description":"Glob pattern to match"},"path":{"type":"string","description":"Directory to search in"}},"required":["pattern"]}`),
+	ToolGrep:      json.RawMessage(`{"type":"object","properties":{"pattern":{"type":"string","description":"Regular expression to search for"},"path":{"type":"string","description":"File or directory to search in"},"glob":{"type":"string","description":"Glob filter for files"}},"required":["pattern"]}`),
+	ToolBash:      json.RawMessage(`{"type":"object","properties":{"command":{"type":"string","description":"The shell command to run"},"timeout":{"type":"integer","description":"Timeout in milliseconds"}},"required":["command"]}`),
+	ToolEdit:      json.RawMessage(`{"type":"object","properties":{"file_path":{"type":"string","description":"Path of the file to edit"},"old_string":{"type":"string","description":"Text to replace"},"new_string":{"type":"string","description":"Replacement text"}},"required":["file_path","old_string","new_string"]}`),
+	ToolTask:      json.RawMessage(`{"type":"object","properties":{"description":{"type":"string","description":"Short summary of the task"},"prompt":{"type":"string","description":"Instructions for the sub-agent"}},"required":["description","prompt"]}`),
+}
+
+// emptyInputSchema is the input schema for custom tools that declare none.
+var emptyInputSchema = json.RawMessage(`{"type":"object"}`)
+
+// anthropicTool is an Anthropic Messages API tool definition.
+type anthropicTool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	InputSchema json.RawMessage `json:"input_schema"`
+}
+
+// ToAnthropicTools renders the agent's tools as a JSON array of Anthropic
+// Messages API tool definitions, in AllTools order. Canonical tools take
+// their description from ToolDescriptions and their input schema from
+// ToolInputSchemas; custom tools use their own Description and Schema. It
+// returns an error for a tool that is neither canonical nor custom. Tool names
+// that resolve to the same canonical tool are emitted once.
+func (a *Agent) ToAnthropicTools() (json.RawMessage, error) {
+	custom := make(map[string]CustomTool, len(a.CustomTools))
+	for _, ct := range a.CustomTools {
+		custom[ct.Name] = ct
+	}
+
+	names := a.AllTools()
+	defs := make([]anthropicTool, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if ct, ok := custom[name]; ok {
+			schema := ct.Schema
+			if len(schema) == 0 {
+				schema = emptyInputSchema
+			}
+			defs = append(defs, anthropicTool{Name: ct.Name, Description: ct.Description, InputSchema: schema})
+			continue
+		}
+		tool, ok := CanonicalTool(name)
+		if !ok {
+			return nil, fmt.Errorf("anthropic tools: unknown tool %q", name)
+		}
+		if seen[string(tool)] {
+			continue
+		}
+		seen[string(tool)] = true
+		defs = append(defs, anthropicTool{
+			Name:        string(tool),
+			Description: ToolDescriptions[tool],
+			InputSchema: ToolInputSchemas[tool],
+		})
+	}
+
+	data, err := json.Marshal(defs)
+	if err != nil {
+		return nil, fmt.Errorf("anthropic tools: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,69 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAgentToAnthropicTools(t *testing.T) {
+	agent := NewAgent("a", "").WithTools("Read", "web_search", "read")
+	agent.CustomTools = []CustomTool{
+		{Name: "lookup", Description: "Look up a record", Schema: json.RawMessage(`{"type":"object","properties":{"id":{"type":"string"}}}`)},
+		{Name: "ping"},
+	}
+
+	data, err := agent.ToAnthropicTools()
+	if err != nil {
+		t.Fatalf("ToAnthropicTools failed: %v", err)
+	}
+
+	var defs []struct {
+		Name        string                 `json:"name"`
+		Description string                 `json:"description"`
+		InputSchema map[string]interface{} `json:"input_schema"`
+	}
+	if err := json.Unmarshal(data, &defs); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v\n%s", err, data)
+	}
+
+	var names []string
+	for _, d := range defs {
+		names = append(names, d.Name)
+	}
+	if got := strings.Join(names, ","); got != "Read,WebSearch,lookup,ping" {
+		t.Fatalf("tool names = %s", got)
+	}
+	if defs[0].Description != ToolDescriptions[ToolRead] {
+		t.Errorf("Read description = %q", defs[0].Description)
+	}
+	if req, _ := defs[0].InputSchema["required"].([]interface{}); len(req) != 1 || req[0] != "file_path" {
+		t.Errorf("Read input_schema = %v", defs[0].InputSchema)
+	}
+	if _, ok := defs[2].InputSchema["properties"]; !ok || defs[2].Description != "Look up a record" {
+		t.Errorf("custom tool = %+v", defs[2])
+	}
+	if defs[3].InputSchema["type"] != "object" {
+		t.Errorf("custom tool without schema = %+v", defs[3])
+	}
+}
+
+func TestAgentToAnthropicToolsUnknown(t *testing.T) {
+	agent := NewAgent("a", "").WithTools("Teleport")
+	if _, err := agent.ToAnthropicTools(); err == nil || !strings.Contains(err.Error(), `unknown tool "Teleport"`) {
+		t.Errorf("ToAnthropicTools error = %v", err)
+	}
+}
+
+func TestToolInputSchemasCoverCanonicalTools(t *testing.T) {
+	for _, tool := range canonicalTools {
+		schema, ok := ToolInputSchemas[tool]
+		if !ok {
+			t.Errorf("no input schema for %s", tool)
+			continue
+		}
+		if err := validateSchemaSyntax(schema); err != nil {
+			t.Errorf("input schema for %s: %v", tool, err)
+		}
+	}
+}
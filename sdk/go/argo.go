@@ -0,0 +1,233 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// argoEntrypoint is the name of the DAG template in generated Argo workflows.
+const argoEntrypoint = "main"
+
+// dns1123Label matches a DNS-1123 label, the form Argo requires of task and
+// template names; labels are also limited to 63 characters.
+var dns1123Label = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// argoWorkflow is an Argo Workflows argoproj.io/v1alpha1 Workflow.
+type argoWorkflow struct {
+	APIVersion string           `yaml:"apiVersion"`
+	Kind       string           `yaml:"kind"`
+	Metadata   argoMetadata     `yaml:"metadata"`
+	Spec       argoWorkflowSpec `yaml:"spec"`
+}
+
+type argoMetadata struct {
+	GenerateName string            `yaml:"generateName"`
+	Namespace    string            `yaml:"namespace,omitempty"`
+	Labels       map[string]string `yaml:"labels,omitempty"`
+}
+
+type argoWorkflowSpec struct {
+	Entrypoint string         `yaml:"entrypoint"`
+	Templates  []argoTemplate `yaml:"templates"`
+}
+
+type argoTemplate struct {
+	Name      string        `yaml:"name"`
+	DAG       *argoDAG      `yaml:"dag,omitempty"`
+	Inputs    *argoIO       `yaml:"inputs,omitempty"`
+	Outputs   *argoIO       `yaml:"outputs,omitempty"`
+	Container *k8sContainer `yaml:"container,omitempty"`
+}
+
+type argoDAG struct {
+	Tasks []argoDAGTask `yaml:"tasks"`
+}
+
+type argoDAGTask struct {
+	Name         string   `yaml:"name"`
+	Template     string   `yaml:"template"`
+	Dependencies []string `yaml:"dependencies,omitempty"`
+	Arguments    *argoIO  `yaml:"arguments,omitempty"`
+}
+
+// argoIO holds the parameters and artifacts of template inputs and outputs,
+// and of task arguments.
+type argoIO struct {
+	Parameters []argoParameter `yaml:"parameters,omitempty"`
+	Artifacts  []argoArtifact  `yaml:"artifacts,omitempty"`
+}
+
+type argoParameter struct {
+	Name      string              `yaml:"name"`
+	Value     string              `yaml:"value,omitempty"`
+	Default   string              `yaml:"default,omitempty"`
+	ValueFrom *argoParameterValue `yaml:"valueFrom,omitempty"`
+}
+
+type argoParameterValue struct {
+	Path string `yaml:"path"`
+}
+
+type argoArtifact struct {
+	Name string `yaml:"name"`
+	Path string `yaml:"path,omitempty"`
+	From string `yaml:"from,omitempty"`
+}
+
+// ToArgoWorkflow renders the workflow as an Argo Workflows Workflow manifest
+// in YAML, in namespace, with agent images from no registry. It is
+// ToArgoWorkflowWithConfig with a KubernetesConfig holding only namespace.
+func (w *Workflow) ToArgoWorkflow(team *Team, namespace string) ([]byte, error) {
+	return w.ToArgoWorkflowWithConfig(team, KubernetesConfig{Namespace: namespace})
+}
+
+// ToArgoWorkflowWithConfig renders the workflow as an Argo Workflows
+// Workflow manifest in YAML, in cfg.Namespace. Each step becomes a task of a
+// DAG template, depending on the tasks in its DependsOn, and runs a container
+// template using the image of the step's agent from cfg (see
+// KubernetesConfig.ImageFor) and the step's Resources, or cfg.ResourceLimits
+// if unset. File ports map to Argo artifacts under /inputs and /outputs;
+// other ports map to parameters, with outputs read from /outputs/<name>.
+// Inputs wired with From are passed from the producing task. It returns an
+// error if team is nil, cfg is invalid (see KubernetesConfig.Validate) or its
+// Namespace is not a DNS-1123 label, the dependencies are invalid (see
+// TopologicalOrder), a step agent is not in the team, or a step name is not a
+// DNS-1123 label or is "main", which is reserved for the DAG template.
+func (w *Workflow) ToArgoWorkflowWithConfig(team *Team, cfg KubernetesConfig) ([]byte, error) {
+	if team == nil {
+		return nil, fmt.Errorf("argo workflow: team is nil")
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("argo workflow: kubernetes config: %w", err)
+	}
+	if len(cfg.Namespace) > 63 || !dns1123Label.MatchString(cfg.Namespace) {
+		return nil, fmt.Errorf("argo workflow: namespace %q is not a DNS-1123 label", cfg.Namespace)
+	}
+	if _, err := w.TopologicalOrder(); err != nil {
+		return nil, fmt.Errorf("argo workflow: %w", err)
+	}
+	check := *team
+	check.Workflow = w
+	if err := check.ValidateWorkflowAgents(); err != nil {
+		return nil, fmt.Errorf("argo workflow: %w", err)
+	}
+
+	steps := w.stepsByName()
+	dag := &argoDAG{}
+	templates := []argoTemplate{{Name: argoEntrypoint, DAG: dag}}
+	for _, step := range w.Steps {
+		switch {
+		case step.Name == argoEntrypoint:
+			return nil, fmt.Errorf("argo workflow: step name %q is reserved", argoEntrypoint)
+		case len(step.Name) > 63 || !dns1123Label.MatchString(step.Name):
+			return nil, fmt.Errorf("argo workflow: step name %q is not a DNS-1123 label", step.Name)
+		}
+
+		task := argoDAGTask{Name: step.Name, Template: step.Name, Dependencies: step.DependsOn}
+		inputs, args := &argoIO{}, &argoIO{}
+		for _, in := range step.Inputs {
+			src, out, wired := parsePortRef(in.From)
+			if wired {
+				if _, ok := steps[src]; !ok {
+					wired = false
+				}
+			}
+			if in.Type == PortTypeFile {
+				inputs.Artifacts = append(inputs.Artifacts, argoArtifact{Name: in.Name, Path: "/inputs/" + in.Name})
+				if wired {
+					args.Artifacts = append(args.Artifacts, argoArtifact{
+						Name: in.Name,
+						From: fmt.Sprintf("{{tasks.%s.outputs.artifacts.%s}}", src, out),
+					})
+				}
+				continue
+			}
+			param := argoParameter{Name: in.Name}
+			if in.Default != nil {
+				def, err := argoValue(in.Default)
+				if err != nil {
+					return nil, fmt.Errorf("argo workflow: step %s input %s: %w", step.Name, in.Name, err)
+				}
+				param.Default = def
+			}
+			inputs.Parameters = append(inputs.Parameters, param)
+			if wired {
+				args.Parameters = append(args.Parameters, argoParameter{
+					Name:  in.Name,
+					Value: fmt.Sprintf("{{tasks.%s.outputs.parameters.%s}}", src, out),
+				})
+			}
+		}
+
+		outputs := &argoIO{}
+		for _, out := range step.Outputs {
+			if out.Type == PortTypeFile {
+				outputs.Artifacts = append(outputs.Artifacts, argoArtifact{Name: out.Name, Path: "/outputs/" + out.Name})
+				continue
+			}
+			outputs.Parameters = append(outputs.Parameters, argoParameter{
+				Name:      out.Name,
+				ValueFrom: &argoParameterValue{Path: "/outputs/" + out.Name},
+			})
+		}
+
+		if len(args.Parameters)+len(args.Artifacts) > 0 {
+			task.Arguments = args
+		}
+		dag.Tasks = append(dag.Tasks, task)
+
+		resources := step.Resources
+		if resources == nil {
+			resources = cfg.ResourceLimits
+		}
+		tmpl := argoTemplate{
+			Name: step.Name,
+			Container: &k8sContainer{
+				Name:  "main",
+				Image: cfg.ImageFor(step.Agent),
+				Env: []k8sEnvVar{
+					{Name: "AGENT_NAME", Value: step.Agent},
+					{Name: "STEP_NAME", Value: step.Name},
+				},
+				Resources: k8sResourcesFor(resources),
+			},
+		}
+		if len(inputs.Parameters)+len(inputs.Artifacts) > 0 {
+			tmpl.Inputs = inputs
+		}
+		if len(outputs.Parameters)+len(outputs.Artifacts) > 0 {
+			tmpl.Outputs = outputs
+		}
+		templates = append(templates, tmpl)
+	}
+
+	wf := argoWorkflow{
+		APIVersion: "argoproj.io/v1alpha1",
+		Kind:       "Workflow",
+		Metadata: argoMetadata{
+			GenerateName: team.Name + "-",
+			Namespace:    cfg.Namespace,
+			Labels:       map[string]string{"app.kubernetes.io/part-of": team.Name},
+		},
+		Spec: argoWorkflowSpec{Entrypoint: argoEntrypoint, Templates: templates},
+	}
+	data, err := marshalYAML(wf)
+	if err != nil {
+		return nil, fmt.Errorf("argo workflow: %w", err)
+	}
+	return data, nil
+}
+
+// argoValue formats a port default as an Argo parameter value: strings as
+// is, other values as JSON.
+func argoValue(v interface{}) (string, error) {
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,122 @@
+package multiagentspec
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestWorkflowToArgoWorkflow(t *testing.T) {
+	w := &Workflow{
+		Type: WorkflowDAG,
+		Steps: []Step{
+			{Name: "fetch", Agent: "fetcher", Outputs: []Port{{Name: "url", Type: PortTypeString}, {Name: "page", Type: PortTypeFile}}},
+			{Name: "summarize", Agent: "writer", DependsOn: []string{"fetch"},
+				Resources: &ResourceLimits{CPU: "500m"},
+				Inputs: []Port{
+					{Name: "url", Type: PortTypeString, From: "fetch.url"},
+					{Name: "page", Type: PortTypeFile, From: "fetch.page"},
+					{Name: "words", Type: PortTypeNumber, Default: 200},
+				}},
+		},
+	}
+	team := NewTeam("research", "1.0.0").WithAgents("fetcher", "writer")
+
+	cfg := KubernetesConfig{Namespace: "agents", ImageRegistry: "ghcr.io/acme", ResourceLimits: &ResourceLimits{Memory: "1Gi"}}
+	data, err := w.ToArgoWorkflowWithConfig(team, cfg)
+	if err != nil {
+		t.Fatalf("ToArgoWorkflowWithConfig failed: %v", err)
+	}
+
+	var wf struct {
+		Kind     string `yaml:"kind"`
+		Metadata struct {
+			GenerateName string `yaml:"generateName"`
+			Namespace    string `yaml:"namespace"`
+		} `yaml:"metadata"`
+		Spec struct {
+			Entrypoint string `yaml:"entrypoint"`
+			Templates  []struct {
+				Name string `yaml:"name"`
+				DAG  struct {
+					Tasks []struct {
+						Name         string   `yaml:"name"`
+						Dependencies []string `yaml:"dependencies"`
+					} `yaml:"tasks"`
+				} `yaml:"dag"`
+				Container struct {
+					Image string `yaml:"image"`
+				} `yaml:"container"`
+			} `yaml:"templates"`
+		} `yaml:"spec"`
+	}
+	if err := yaml.Unmarshal(data, &wf); err != nil {
+		t.Fatalf("yaml.Unmarshal failed: %v\n%s", err, data)
+	}
+	if wf.Kind != "Workflow" || wf.Metadata.GenerateName != "research-" || wf.Metadata.Namespace != "agents" || wf.Spec.Entrypoint != "main" {
+		t.Errorf("workflow header = %+v", wf)
+	}
+	if len(wf.Spec.Templates) != 3 {
+		t.Fatalf("templates = %d, want 3\n%s", len(wf.Spec.Templates), data)
+	}
+	tasks := wf.Spec.Templates[0].DAG.Tasks
+	if len(tasks) != 2 || tasks[1].Name != "summarize" || strings.Join(tasks[1].Dependencies, ",") != "fetch" {
+		t.Errorf("dag tasks = %+v", tasks)
+	}
+	if img := wf.Spec.Templates[2].Container.Image; img != "ghcr.io/acme/writer:latest" {
+		t.Errorf("summarize image = %q", img)
+	}
+	for _, want := range []string{
+		"value: '{{tasks.fetch.outputs.parameters.url}}'",
+		"from: '{{tasks.fetch.outputs.artifacts.page}}'",
+		"path: /outputs/url",
+		"path: /inputs/page",
+		`default: "200"`,
+		"cpu: 500m",
+		"memory: 1Gi",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("manifest missing %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestWorkflowToArgoWorkflowErrors(t *testing.T) {
+	team := NewTeam("t", "1.0.0").WithAgents("a")
+	tests := []struct {
+		steps []Step
+		want  string
+	}{
+		{[]Step{{Name: "s", Agent: "a", DependsOn: []string{"ghost"}}}, "depends on unknown step ghost"},
+		{[]Step{{Name: "s", Agent: "b"}}, `uses "b", which is not a team agent`},
+		{[]Step{{Name: "main", Agent: "a"}}, `step name "main" is reserved`},
+		{[]Step{{Name: "Fetch_Page", Agent: "a"}}, `step name "Fetch_Page" is not a DNS-1123 label`},
+		{[]Step{{Name: strings.Repeat("s", 64), Agent: "a"}}, "is not a DNS-1123 label"},
+	}
+	for _, tt := range tests {
+		_, err := (&Workflow{Steps: tt.steps}).ToArgoWorkflow(team, "agents")
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ToArgoWorkflow(%+v) error = %v, want %q", tt.steps, err, tt.want)
+		}
+	}
+	valid := &Workflow{Steps: []Step{{Name: "s", Agent: "a"}}}
+	if _, err := valid.ToArgoWorkflow(nil, "agents"); err == nil {
+		t.Error("ToArgoWorkflow should fail for a nil team")
+	}
+	if data, err := valid.ToArgoWorkflow(team, "agents"); err != nil || !strings.Contains(string(data), "image: a:latest") {
+		t.Errorf("ToArgoWorkflow() = %s, %v", data, err)
+	}
+	for _, tt := range []struct {
+		cfg  KubernetesConfig
+		want string
+	}{
+		{KubernetesConfig{}, `namespace "" is not a DNS-1123 label`},
+		{KubernetesConfig{Namespace: "Agents"}, `namespace "Agents" is not a DNS-1123 label`},
+		{KubernetesConfig{Namespace: "agents", ImageRegistry: "https://ghcr.io"}, "kubernetes config: imageRegistry"},
+	} {
+		if _, err := valid.ToArgoWorkflowWithConfig(team, tt.cfg); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ToArgoWorkflowWithConfig(%+v) error = %v, want %q", tt.cfg, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+// ArtifactPaths returns, per target name, the paths of the files the target
+// would generate for agents, relative to the target's Output directory.
+// Platforms without a known artifact layout yield no paths. It returns an
+// error if two targets, or two agents within one target, would write the same
+// file; paths are compared as absolute paths, with relative Output
+// directories resolved against the working directory.
+//
+// Layouts: claude-code writes "<agent>.md" and ClaudeCodeContextFile, as its
+// built-in generator does (see GetGenerator), kiro-cli writes "<agent>.json"
+// (namespaced agents in subdirectories), agentkit-local writes a single
+// "config.json", and the Kubernetes platforms write "<agent>-deployment.yaml"
+// as GenerateKubernetesManifests does.
+func (d *Deployment) ArtifactPaths(agents []*Agent) (map[string][]string, error) {
+	paths := make(map[string][]string, len(d.Targets))
+	writers := make(map[string]string)
+
+	for _, t := range d.Targets {
+		rel := targetArtifacts(t, agents)
+		sort.Strings(rel)
+		for _, p := range rel {
+			full, err := filepath.Abs(filepath.Join(t.Output, p))
+			if err != nil {
+				return nil, fmt.Errorf("target %s: %w", t.Name, err)
+			}
+			if owner, ok := writers[full]; ok {
+				if owner == t.Name {
+					return nil, fmt.Errorf("target %s writes %s more than once", t.Name, full)
+				}
+				return nil, fmt.Errorf("targets %s and %s both write %s", owner, t.Name, full)
+			}
+			writers[full] = t.Name
+		}
+		paths[t.Name] = rel
+	}
+	return paths, nil
+}
+
+// targetArtifacts returns the relative artifact paths for a single target.
+func targetArtifacts(t Target, agents []*Agent) []string {
+	var perAgent func(a *Agent) string
+	switch t.Platform {
+	case PlatformClaudeCode:
+		rel := []string{ClaudeCodeContextFile}
+		for _, a := range agents {
+			rel = append(rel, filepath.FromSlash(claudeCodeAgentPath(a)))
+		}
+		return rel
+	case PlatformKiroCLI:
+		perAgent = func(a *Agent) string { return filepath.FromSlash(a.QualifiedName()) + ".json" }
+	case PlatformKubernetes, PlatformAWSEKS, PlatformAzureAKS, PlatformGCPGKE:
+		perAgent = func(a *Agent) string { return a.Name + "-deployment.yaml" }
+	case PlatformAgentKitLocal:
+		return []string{"config.json"}
+	default:
+		return nil
+	}
+
+	rel := make([]string, 0, len(agents))
+	for _, a := range agents {
+		rel = append(rel, perAgent(a))
+	}
+	return rel
+}
@@ -0,0 +1,96 @@
+package multiagentspec
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestDeploymentArtifactPaths(t *testing.T) {
+	agents := []*Agent{NewAgent("research", ""), NewAgent("lead", "").WithNamespace("prd")}
+	d := NewDeployment("t").
+		AddTarget(Target{Name: "claude", Platform: PlatformClaudeCode, Output: ".claude/agents"}).
+		AddTarget(Target{Name: "kiro", Platform: PlatformKiroCLI, Output: "plugins/kiro/agents"}).
+		AddTarget(Target{Name: "agentkit", Platform: PlatformAgentKitLocal, Output: "plugins/agentkit"}).
+		AddTarget(Target{Name: "eks", Platform: PlatformAWSEKS, Output: "k8s"}).
+		AddTarget(Target{Name: "crew", Platform: PlatformCrewAI, Output: "crew"})
+
+	paths, err := d.ArtifactPaths(agents)
+	if err != nil {
+		t.Fatalf("ArtifactPaths failed: %v", err)
+	}
+
+	want := map[string]string{
+		"claude":   "CLAUDE.md,lead.md,research.md",
+		"kiro":     filepath.Join("prd", "lead.json") + "," + "research.json",
+		"agentkit": "config.json",
+		"eks":      "lead-deployment.yaml,research-deployment.yaml",
+		"crew":     "",
+	}
+	for name, w := range want {
+		if got := strings.Join(paths[name], ","); got != w {
+			t.Errorf("paths[%s] = %s, want %s", name, got, w)
+		}
+	}
+}
+
+func TestDeploymentArtifactPathsMatchGenerators(t *testing.T) {
+	agents := []*Agent{NewAgent("research", ""), NewAgent("lead", "").WithNamespace("prd")}
+	team := NewTeam("t", "1.0.0").WithAgents("research", "lead")
+	d := NewDeployment("t").
+		AddTarget(Target{Name: "claude", Platform: PlatformClaudeCode, Output: ".claude/agents"}).
+		AddTarget(Target{Name: "eks", Platform: PlatformAWSEKS, Output: "k8s"})
+	if err := d.FillDefaults(); err != nil {
+		t.Fatal(err)
+	}
+
+	paths, err := d.ArtifactPaths(agents)
+	if err != nil {
+		t.Fatalf("ArtifactPaths failed: %v", err)
+	}
+	for _, target := range d.Targets {
+		g, _ := GetGenerator(target.Platform)
+		files, err := g.Generate(target, team, agents)
+		if err != nil {
+			t.Fatalf("%s: Generate failed: %v", target.Name, err)
+		}
+		var generated []string
+		for name := range files {
+			generated = append(generated, filepath.FromSlash(name))
+		}
+		sort.Strings(generated)
+		if got, want := strings.Join(paths[target.Name], ","), strings.Join(generated, ","); got != want {
+			t.Errorf("%s: ArtifactPaths = %s, generated %s", target.Name, got, want)
+		}
+	}
+}
+
+func TestDeploymentArtifactPathsCollision(t *testing.T) {
+	agents := []*Agent{NewAgent("research", "")}
+	d := NewDeployment("t").
+		AddTarget(Target{Name: "eks", Platform: PlatformAWSEKS, Output: "deploy"}).
+		AddTarget(Target{Name: "gke", Platform: PlatformGCPGKE, Output: "deploy/"})
+
+	_, err := d.ArtifactPaths(agents)
+	if err == nil || !strings.Contains(err.Error(), "targets eks and gke both write") {
+		t.Errorf("ArtifactPaths error = %v, want collision error", err)
+	}
+
+	abs, err := filepath.Abs("deploy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mixed := NewDeployment("t").
+		AddTarget(Target{Name: "eks", Platform: PlatformAWSEKS, Output: "deploy"}).
+		AddTarget(Target{Name: "gke", Platform: PlatformGCPGKE, Output: abs})
+	if _, err := mixed.ArtifactPaths(agents); err == nil || !strings.Contains(err.Error(), "targets eks and gke both write") {
+		t.Errorf("ArtifactPaths error = %v, want collision error for relative and absolute outputs", err)
+	}
+
+	dup := NewDeployment("t").AddTarget(Target{Name: "eks", Platform: PlatformAWSEKS})
+	_, err = dup.ArtifactPaths([]*Agent{NewAgent("a", ""), NewAgent("a", "").WithNamespace("x")})
+	if err == nil || !strings.Contains(err.Error(), "more than once") {
+		t.Errorf("ArtifactPaths error = %v, want duplicate error", err)
+	}
+}
@@ -0,0 +1,67 @@
+package multiagentspec
+
+import "sort"
+
+// Canonicalize rewrites the agent into a canonical form so that equivalent
+// definitions marshal identically. Tool names in Tools and AllowedTools are
+// normalized with CanonicalTool; Tools, AllowedTools, Skills, Dependencies,
+// and Requires are sorted and deduplicated; CustomTools are sorted by name
+// and Tasks by ID. ModelFallback keeps its order, which is significant.
+func (a *Agent) Canonicalize() {
+	a.Tools = sortedUnique(canonicalToolNames(a.Tools))
+	a.AllowedTools = sortedUnique(canonicalToolNames(a.AllowedTools))
+	a.Skills = sortedUnique(a.Skills)
+	a.Dependencies = sortedUnique(a.Dependencies)
+	a.Requires = sortedUnique(a.Requires)
+	sort.SliceStable(a.CustomTools, func(i, j int) bool {
+		return a.CustomTools[i].Name < a.CustomTools[j].Name
+	})
+	sort.SliceStable(a.Tasks, func(i, j int) bool {
+		return a.Tasks[i].ID < a.Tasks[j].ID
+	})
+}
+
+// Canonicalize rewrites the team into a canonical form so that equivalent
+// definitions marshal identically. Agents and each step's DependsOn are sorted
+// and deduplicated, and Protocols are sorted by From then To. Workflow steps
+// keep their order, which is significant for sequential workflows.
+func (t *Team) Canonicalize() {
+	t.Agents = sortedUnique(t.Agents)
+	if t.Workflow != nil {
+		for i := range t.Workflow.Steps {
+			t.Workflow.Steps[i].DependsOn = sortedUnique(t.Workflow.Steps[i].DependsOn)
+		}
+	}
+	sort.SliceStable(t.Protocols, func(i, j int) bool {
+		if t.Protocols[i].From != t.Protocols[j].From {
+			return t.Protocols[i].From < t.Protocols[j].From
+		}
+		return t.Protocols[i].To < t.Protocols[j].To
+	})
+}
+
+// canonicalToolNames replaces each recognized tool name with its canonical
+// spelling, leaving unrecognized names unchanged.
+func canonicalToolNames(names []string) []string {
+	for i, name := range names {
+		if tool, ok := CanonicalTool(name); ok {
+			names[i] = string(tool)
+		}
+	}
+	return names
+}
+
+// sortedUnique sorts ss in place and removes duplicates.
+func sortedUnique(ss []string) []string {
+	if len(ss) == 0 {
+		return ss
+	}
+	sort.Strings(ss)
+	out := ss[:1]
+	for _, s := range ss[1:] {
+		if s != out[len(out)-1] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
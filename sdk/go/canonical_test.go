@@ -0,0 +1,58 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestAgentCanonicalize(t *testing.T) {
+	a := NewAgent("a", "").WithTools("web_search", "Read", "read", "mcp__db")
+	a.AllowedTools = []string{"bash", "Bash"}
+	a.Skills = []string{"z", "a", "z"}
+	a.Dependencies = []string{"research", "lead", "research"}
+	a.Requires = []string{"go", "git"}
+	a.ModelFallback = []Model{ModelOpus, ModelHaiku}
+	a.CustomTools = []CustomTool{{Name: "zip"}, {Name: "lookup"}}
+	a.Tasks = []Task{{ID: "vet"}, {ID: "build"}}
+
+	a.Canonicalize()
+
+	want := &Agent{
+		Name:          "a",
+		Model:         ModelSonnet,
+		Tools:         []string{"Read", "WebSearch", "mcp__db"},
+		AllowedTools:  []string{"Bash"},
+		Skills:        []string{"a", "z"},
+		Dependencies:  []string{"lead", "research"},
+		Requires:      []string{"git", "go"},
+		ModelFallback: []Model{ModelOpus, ModelHaiku},
+		CustomTools:   []CustomTool{{Name: "lookup"}, {Name: "zip"}},
+		Tasks:         []Task{{ID: "build"}, {ID: "vet"}},
+	}
+	got, _ := json.Marshal(a)
+	exp, _ := json.Marshal(want)
+	if string(got) != string(exp) {
+		t.Errorf("Canonicalize() =\n%s\nwant\n%s", got, exp)
+	}
+}
+
+func TestTeamCanonicalize(t *testing.T) {
+	team := NewTeam("t", "1.0.0").WithAgents("writer", "lead", "writer").WithWorkflow(&Workflow{Steps: []Step{
+		{Name: "b", Agent: "lead"},
+		{Name: "a", Agent: "writer", DependsOn: []string{"b", "a0", "b"}},
+	}})
+	team.Protocols = []Protocol{{From: "writer", To: "lead"}, {From: "lead", To: "writer"}}
+
+	team.Canonicalize()
+
+	if !reflect.DeepEqual(team.Agents, []string{"lead", "writer"}) {
+		t.Errorf("Agents = %v", team.Agents)
+	}
+	if team.Workflow.Steps[0].Name != "b" || !reflect.DeepEqual(team.Workflow.Steps[1].DependsOn, []string{"a0", "b"}) {
+		t.Errorf("Steps = %+v", team.Workflow.Steps)
+	}
+	if team.Protocols[0].From != "lead" {
+		t.Errorf("Protocols = %+v", team.Protocols)
+	}
+}
@@ -0,0 +1,146 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ClaudeCodeContextFile is the project-level context file written by
+// ToClaudeCodeProject.
+const ClaudeCodeContextFile = "CLAUDE.md"
+
+// claudeCodeFrontmatter is the YAML frontmatter of a Claude Code subagent
+// file.
+type claudeCodeFrontmatter struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	Tools       string `yaml:"tools,omitempty"`
+	Model       string `yaml:"model,omitempty"`
+}
+
+// ToClaudeCodeProject renders the team as a Claude Code project: one
+// "<AgentDir>/<agent>.md" subagent file per team agent, in team order, plus
+// a ClaudeCodeContextFile describing the team, its shared Context, and its
+// workflow. Agent files carry the agent's description, tools (canonical
+// names, followed by custom tools), and model for Claude Code (see
+// Agent.ModelFor) in their frontmatter and the Instructions as the body.
+// Empty cfg fields use the platform defaults. It returns an error if a team
+// agent is missing from agents, two team agents share a name, or cfg.Format
+// is not "markdown".
+func (t *Team) ToClaudeCodeProject(agents []*Agent, cfg ClaudeCodeConfig) (map[string][]byte, error) {
+	if cfg.AgentDir == "" {
+		cfg.AgentDir = PlatformClaudeCode.DefaultConfig().(*ClaudeCodeConfig).AgentDir
+	}
+	return t.claudeCodeProject(agents, cfg, fsPath(cfg.AgentDir))
+}
+
+// claudeCodeProject renders the files of ToClaudeCodeProject, placing the
+// subagent files in agentDir.
+func (t *Team) claudeCodeProject(agents []*Agent, cfg ClaudeCodeConfig, agentDir string) (map[string][]byte, error) {
+	if cfg.Format == "" {
+		cfg.Format = PlatformClaudeCode.DefaultConfig().(*ClaudeCodeConfig).Format
+	}
+	if cfg.Format != "markdown" {
+		return nil, fmt.Errorf("claude code project: unsupported format %q", cfg.Format)
+	}
+
+	members, err := teamMembers(t, agents)
+	if err != nil {
+		return nil, fmt.Errorf("claude code project: %w", err)
+	}
+
+	files := make(map[string][]byte, len(members)+1)
+	for _, agent := range members {
+		name := path.Join(agentDir, claudeCodeAgentPath(agent))
+		if _, exists := files[name]; exists {
+			return nil, fmt.Errorf("claude code project: agents share the file %s", name)
+		}
+		data, err := claudeCodeAgentFile(agent)
+		if err != nil {
+			return nil, fmt.Errorf("claude code project: agent %s: %w", agent.Name, err)
+		}
+		files[name] = data
+	}
+	files[ClaudeCodeContextFile] = t.claudeCodeContext()
+	return files, nil
+}
+
+// claudeCodeAgentPath returns the path of the agent's subagent file,
+// relative to the agent directory.
+func claudeCodeAgentPath(a *Agent) string {
+	return a.Name + ".md"
+}
+
+// claudeCodeAgentFile renders a Claude Code subagent markdown file.
+func claudeCodeAgentFile(a *Agent) ([]byte, error) {
+	seen := make(map[string]bool)
+	var tools []string
+	for _, name := range a.AllTools() {
+		if tool, ok := CanonicalTool(name); ok {
+			name = string(tool)
+		}
+		if !seen[name] {
+			seen[name] = true
+			tools = append(tools, name)
+		}
+	}
+
+	frontmatter, err := marshalYAML(claudeCodeFrontmatter{
+		Name:        a.Name,
+		Description: a.Description,
+		Tools:       strings.Join(tools, ", "),
+		Model:       MapModelToClaudeCode(a.ModelFor(PlatformClaudeCode)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	b.Write(frontmatter)
+	b.WriteString("---\n")
+	if a.Instructions != "" {
+		fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(a.Instructions))
+	}
+	return []byte(b.String()), nil
+}
+
+// claudeCodeContext renders the project-level context file for the team.
+func (t *Team) claudeCodeContext() []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", t.Name)
+	if t.Description != "" {
+		fmt.Fprintf(&b, "\n%s\n", t.Description)
+	}
+
+	b.WriteString("\n## Agents\n\n")
+	for _, name := range t.Agents {
+		if name == t.Orchestrator {
+			fmt.Fprintf(&b, "- %s (orchestrator)\n", name)
+		} else {
+			fmt.Fprintf(&b, "- %s\n", name)
+		}
+	}
+
+	if t.Context != "" {
+		fmt.Fprintf(&b, "\n## Context\n\n%s\n", strings.TrimSpace(t.Context))
+	}
+
+	if t.Workflow != nil && len(t.Workflow.Steps) > 0 {
+		b.WriteString("\n## Workflow\n\n")
+		typ := t.Workflow.Type
+		if typ == "" {
+			typ = WorkflowOrchestrated
+		}
+		fmt.Fprintf(&b, "Type: %s\n\n", typ)
+		for i, step := range t.Workflow.Steps {
+			fmt.Fprintf(&b, "%d. **%s** (%s)", i+1, step.Name, step.Agent)
+			if len(step.DependsOn) > 0 {
+				fmt.Fprintf(&b, " after %s", strings.Join(step.DependsOn, ", "))
+			}
+			b.WriteString("\n")
+		}
+	}
+	return []byte(b.String())
+}
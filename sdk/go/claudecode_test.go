@@ -0,0 +1,88 @@
+package multiagentspec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTeamToClaudeCodeProject(t *testing.T) {
+	lead := NewAgent("lead", "Coordinates research").WithTools("task", "Read")
+	lead.Instructions = "Delegate to the researcher."
+	lead.ModelByPlatform = map[Platform]Model{PlatformClaudeCode: ModelOpus}
+	researcher := NewAgent("researcher", "Finds sources").WithModel(ModelHaiku).WithTools("WebSearch")
+	researcher.CustomTools = []CustomTool{{Name: "lookup"}}
+
+	team := NewTeam("research", "1.0.0").WithAgents("lead", "researcher").WithOrchestrator("lead").
+		WithWorkflow(&Workflow{Steps: []Step{
+			{Name: "plan", Agent: "lead"},
+			{Name: "search", Agent: "researcher", DependsOn: []string{"plan"}},
+		}})
+	team.Description = "Answers research questions."
+	team.Context = "Cite every source."
+
+	files, err := team.ToClaudeCodeProject([]*Agent{researcher, lead}, ClaudeCodeConfig{})
+	if err != nil {
+		t.Fatalf("ToClaudeCodeProject failed: %v", err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("files = %v", files)
+	}
+
+	wantLead := `---
+name: lead
+description: Coordinates research
+tools: Task, Read
+model: opus
+---
+
+Delegate to the researcher.
+`
+	if got := string(files[".claude/agents/lead.md"]); got != wantLead {
+		t.Errorf("lead.md =\n%s\nwant\n%s", got, wantLead)
+	}
+	if got := string(files[".claude/agents/researcher.md"]); !strings.Contains(got, "tools: WebSearch, lookup\nmodel: haiku\n") {
+		t.Errorf("researcher.md =\n%s", got)
+	}
+
+	wantContext := `# research
+
+Answers research questions.
+
+## Agents
+
+- lead (orchestrator)
+- researcher
+
+## Context
+
+Cite every source.
+
+## Workflow
+
+Type: orchestrated
+
+1. **plan** (lead)
+2. **search** (researcher) after plan
+`
+	if got := string(files[ClaudeCodeContextFile]); got != wantContext {
+		t.Errorf("%s =\n%s\nwant\n%s", ClaudeCodeContextFile, got, wantContext)
+	}
+
+	files, err = team.ToClaudeCodeProject([]*Agent{researcher, lead}, ClaudeCodeConfig{AgentDir: "./agents/"})
+	if err != nil {
+		t.Fatalf("ToClaudeCodeProject failed: %v", err)
+	}
+	if _, ok := files["agents/lead.md"]; !ok {
+		t.Errorf("files = %v, want agents/lead.md", files)
+	}
+}
+
+func TestTeamToClaudeCodeProjectErrors(t *testing.T) {
+	team := NewTeam("t", "1.0.0").WithAgents("a")
+	if _, err := team.ToClaudeCodeProject(nil, ClaudeCodeConfig{}); err == nil {
+		t.Error("ToClaudeCodeProject should fail for a missing agent")
+	}
+	if _, err := team.ToClaudeCodeProject([]*Agent{NewAgent("a", "")}, ClaudeCodeConfig{Format: "json"}); err == nil {
+		t.Error("ToClaudeCodeProject should fail for an unsupported format")
+	}
+}
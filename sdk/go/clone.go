@@ -0,0 +1,223 @@
+package multiagentspec
+
+import "encoding/json"
+
+// Clone returns a deep copy of the agent, so the clone can be modified
+// without affecting a.
+func (a *Agent) Clone() *Agent {
+	if a == nil {
+		return nil
+	}
+	c := *a
+	if a.ModelFallback != nil {
+		c.ModelFallback = append([]Model{}, a.ModelFallback...)
+	}
+	if a.ModelByPlatform != nil {
+		c.ModelByPlatform = make(map[Platform]Model, len(a.ModelByPlatform))
+		for p, m := range a.ModelByPlatform {
+			c.ModelByPlatform[p] = m
+		}
+	}
+	c.Tools = cloneStrings(a.Tools)
+	c.AllowedTools = cloneStrings(a.AllowedTools)
+	c.Skills = cloneStrings(a.Skills)
+	c.Dependencies = cloneStrings(a.Dependencies)
+	c.Requires = cloneStrings(a.Requires)
+	c.Env = cloneStringMap(a.Env)
+	if a.CustomTools != nil {
+		c.CustomTools = make([]CustomTool, len(a.CustomTools))
+		for i, ct := range a.CustomTools {
+			if ct.Schema != nil {
+				ct.Schema = append([]byte{}, ct.Schema...)
+			}
+			c.CustomTools[i] = ct
+		}
+	}
+	if a.Tasks != nil {
+		c.Tasks = make([]Task, len(a.Tasks))
+		for i, task := range a.Tasks {
+			if task.Required != nil {
+				required := *task.Required
+				task.Required = &required
+			}
+			c.Tasks[i] = task
+		}
+	}
+	if a.Memory != nil {
+		memory := *a.Memory
+		c.Memory = &memory
+	}
+	if a.RateLimit != nil {
+		limit := *a.RateLimit
+		c.RateLimit = &limit
+	}
+	if a.OutputSchema != nil {
+		c.OutputSchema = append(json.RawMessage{}, a.OutputSchema...)
+	}
+	return &c
+}
+
+// Clone returns a deep copy of the target. Platform configurations, runtime
+// settings, and their nested slices and maps are copied, so the clone can be
+// modified without affecting t.
+func (t Target) Clone() Target {
+	c := t
+	c.Labels = cloneStringMap(t.Labels)
+	c.Runtime = t.Runtime.clone()
+
+	if t.ClaudeCode != nil {
+		v := *t.ClaudeCode
+		c.ClaudeCode = &v
+	}
+	if t.GeminiCLI != nil {
+		v := *t.GeminiCLI
+		c.GeminiCLI = &v
+	}
+	if t.KiroCLI != nil {
+		v := *t.KiroCLI
+		c.KiroCLI = &v
+	}
+	if t.ADKGo != nil {
+		v := *t.ADKGo
+		c.ADKGo = &v
+	}
+	if t.CrewAI != nil {
+		v := *t.CrewAI
+		c.CrewAI = &v
+	}
+	if t.AutoGen != nil {
+		v := *t.AutoGen
+		if v.CodeExecutionConfig != nil {
+			exec := *v.CodeExecutionConfig
+			v.CodeExecutionConfig = &exec
+		}
+		c.AutoGen = &v
+	}
+	if t.AWSAgentCore != nil {
+		v := *t.AWSAgentCore
+		c.AWSAgentCore = &v
+	}
+	c.Kubernetes = t.Kubernetes.clone()
+	if t.DockerCompose != nil {
+		v := *t.DockerCompose
+		c.DockerCompose = &v
+	}
+	if t.AgentKitLocal != nil {
+		v := *t.AgentKitLocal
+		c.AgentKitLocal = &v
+	}
+	return c
+}
+
+// Clone returns a deep copy of the deployment, cloning every target.
+func (d *Deployment) Clone() *Deployment {
+	if d == nil {
+		return nil
+	}
+	c := *d
+	if d.Targets != nil {
+		c.Targets = make([]Target, len(d.Targets))
+		for i, t := range d.Targets {
+			c.Targets[i] = t.Clone()
+		}
+	}
+	return &c
+}
+
+// clone returns a deep copy of the Kubernetes configuration.
+func (k *KubernetesConfig) clone() *KubernetesConfig {
+	if k == nil {
+		return nil
+	}
+	c := *k
+	c.ResourceLimits = k.ResourceLimits.clone()
+	if k.Probes != nil {
+		probes := *k.Probes
+		c.Probes = &probes
+	}
+	if k.Sidecars != nil {
+		c.Sidecars = make([]Container, len(k.Sidecars))
+		for i, sc := range k.Sidecars {
+			sc.Env = cloneStringMap(sc.Env)
+			sc.ResourceLimits = sc.ResourceLimits.clone()
+			c.Sidecars[i] = sc
+		}
+	}
+	return &c
+}
+
+// clone returns a copy of the resource limits.
+func (r *ResourceLimits) clone() *ResourceLimits {
+	if r == nil {
+		return nil
+	}
+	c := *r
+	return &c
+}
+
+// clone returns a deep copy of the runtime configuration.
+func (r *RuntimeConfig) clone() *RuntimeConfig {
+	if r == nil {
+		return nil
+	}
+	c := *r
+	c.Defaults = r.Defaults.clone()
+	if r.Steps != nil {
+		c.Steps = make(map[string]*StepRuntime, len(r.Steps))
+		for name, s := range r.Steps {
+			c.Steps[name] = s.clone()
+		}
+	}
+	if r.Observability != nil {
+		o := *r.Observability
+		if o.Tracing != nil {
+			v := *o.Tracing
+			o.Tracing = &v
+		}
+		if o.Metrics != nil {
+			v := *o.Metrics
+			o.Metrics = &v
+		}
+		if o.Logging != nil {
+			v := *o.Logging
+			o.Logging = &v
+		}
+		c.Observability = &o
+	}
+	return &c
+}
+
+// clone returns a deep copy of the step runtime settings.
+func (s *StepRuntime) clone() *StepRuntime {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	if s.Retry != nil {
+		retry := *s.Retry
+		retry.RetryableErrors = append([]string(nil), s.Retry.RetryableErrors...)
+		c.Retry = &retry
+	}
+	c.Resources = s.Resources.clone()
+	return &c
+}
+
+// cloneStrings returns a copy of s, or nil if s is nil.
+func cloneStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string{}, s...)
+}
+
+// cloneStringMap returns a copy of m, or nil if m is nil.
+func cloneStringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
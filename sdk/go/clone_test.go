@@ -0,0 +1,110 @@
+package multiagentspec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func cloneTestTarget() Target {
+	return Target{
+		Name:     "eks",
+		Platform: PlatformAWSEKS,
+		Runtime: &RuntimeConfig{
+			Defaults: &StepRuntime{
+				Timeout:   "5m",
+				Retry:     &RetryPolicy{MaxAttempts: 3, RetryableErrors: []string{"timeout"}},
+				Resources: &ResourceLimits{CPU: "500m"},
+			},
+			Steps:         map[string]*StepRuntime{"research": {Timeout: "10m"}},
+			Observability: &ObservabilityConfig{Tracing: &TracingConfig{Enabled: true}},
+		},
+		Kubernetes: &KubernetesConfig{
+			Namespace:      "agents",
+			ResourceLimits: &ResourceLimits{Memory: "512Mi"},
+			Sidecars:       []Container{{Name: "proxy", Image: "envoy", Env: map[string]string{"MODE": "sidecar"}}},
+			Probes:         &ProbeConfig{LivenessPath: "/live"},
+		},
+		Labels:  map[string]string{"owner": "data"},
+		AutoGen: &AutoGenConfig{CodeExecutionConfig: &CodeExecutionConfig{WorkDir: "/tmp"}},
+	}
+}
+
+func TestTargetClone(t *testing.T) {
+	orig := cloneTestTarget()
+	c := orig.Clone()
+
+	if !reflect.DeepEqual(orig, c) {
+		t.Fatal("clone differs from original")
+	}
+
+	c.Runtime.Defaults.Retry.RetryableErrors[0] = "5xx"
+	c.Runtime.Defaults.Resources.CPU = "2"
+	c.Runtime.Steps["research"].Timeout = "1m"
+	c.Runtime.Observability.Tracing.Enabled = false
+	c.Kubernetes.Namespace = "staging"
+	c.Kubernetes.ResourceLimits.Memory = "8Gi"
+	c.Kubernetes.Sidecars[0].Env["MODE"] = "edge"
+	c.Kubernetes.Probes.LivenessPath = "/healthz"
+	c.Labels["owner"] = "ops"
+	c.AutoGen.CodeExecutionConfig.WorkDir = "/work"
+
+	if !reflect.DeepEqual(orig, cloneTestTarget()) {
+		t.Errorf("mutating the clone changed the original: %+v", orig)
+	}
+}
+
+func TestDeploymentClone(t *testing.T) {
+	d := NewDeployment("t").AddTarget(cloneTestTarget())
+	c := d.Clone()
+
+	c.Targets[0].Kubernetes.Sidecars[0].Image = "nginx"
+	c.Targets = append(c.Targets, Target{Name: "extra"})
+
+	if d.Targets[0].Kubernetes.Sidecars[0].Image != "envoy" || len(d.Targets) != 1 {
+		t.Error("mutating the clone changed the original deployment")
+	}
+	if (*Deployment)(nil).Clone() != nil {
+		t.Error("Clone of nil deployment should be nil")
+	}
+}
+
+func cloneTestAgent() *Agent {
+	required := true
+	a := NewAgent("a", "desc").WithTools("Read").WithInstructions("Be brief.")
+	a.ModelFallback = []Model{ModelHaiku}
+	a.CustomTools = []CustomTool{{Name: "lookup", Schema: []byte(`{"type":"object"}`)}}
+	a.AllowedTools = []string{"Read"}
+	a.Dependencies = []string{"b"}
+	a.Requires = []string{"git"}
+	a.Env = map[string]string{"MODE": "fast"}
+	a.Tasks = []Task{{ID: "t", Required: &required}}
+	a.Memory = &MemoryConfig{Type: MemoryEphemeral}
+	a.RateLimit = &RateLimitConfig{RequestsPerMinute: 10}
+	return a
+}
+
+func TestAgentClone(t *testing.T) {
+	orig := cloneTestAgent()
+	c := orig.Clone()
+	if !reflect.DeepEqual(orig, c) {
+		t.Fatal("clone differs from original")
+	}
+
+	c.Tools[0] = "Bash"
+	c.ModelFallback[0] = ModelOpus
+	c.CustomTools[0].Schema[0] = '['
+	c.AllowedTools[0] = "Bash"
+	c.Dependencies[0] = "z"
+	c.Requires[0] = "go"
+	c.Env["MODE"] = "slow"
+	*c.Tasks[0].Required = false
+	c.Memory.Type = MemoryPersistent
+	c.RateLimit.RequestsPerMinute = 1
+
+	if !reflect.DeepEqual(orig, cloneTestAgent()) {
+		t.Errorf("mutating the clone changed the original: %+v", orig)
+	}
+	if (*Agent)(nil).Clone() != nil {
+		t.Error("Clone() of nil agent should be nil")
+	}
+}
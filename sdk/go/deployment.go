@@ -1,5 +1,17 @@
 package multiagentspec
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
 // Platform represents supported deployment platforms.
 type Platform string
 
@@ -19,6 +31,60 @@ const (
 	PlatformAgentKitLocal Platform = "agentkit-local"
 )
 
+// platforms lists every supported Platform.
+var platforms = []Platform{
+	PlatformClaudeCode, PlatformGeminiCLI, PlatformKiroCLI, PlatformADKGo,
+	PlatformCrewAI, PlatformAutoGen, PlatformAWSAgentCore, PlatformAWSEKS,
+	PlatformAzureAKS, PlatformGCPGKE, PlatformKubernetes, PlatformDockerCompose,
+	PlatformAgentKitLocal,
+}
+
+// Valid reports whether p is a supported platform.
+func (p Platform) Valid() bool {
+	for _, known := range platforms {
+		if p == known {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultConfig returns a new default configuration for the platform, as a
+// pointer to the platform's config type (e.g., *ClaudeCodeConfig for
+// PlatformClaudeCode). The managed Kubernetes platforms share
+// *KubernetesConfig. It returns nil for unknown platforms.
+func (p Platform) DefaultConfig() interface{} {
+	switch p {
+	case PlatformClaudeCode:
+		return &ClaudeCodeConfig{AgentDir: ".claude/agents", Format: "markdown"}
+	case PlatformGeminiCLI:
+		return &GeminiCLIConfig{ConfigDir: ".gemini"}
+	case PlatformKiroCLI:
+		return &KiroCLIConfig{PluginDir: ".kiro/agents", Format: "json"}
+	case PlatformADKGo:
+		return &ADKGoConfig{ServerPort: 8080}
+	case PlatformCrewAI:
+		return &CrewAIConfig{ProcessType: "sequential"}
+	case PlatformAutoGen:
+		return &AutoGenConfig{HumanInputMode: "NEVER"}
+	case PlatformAWSAgentCore:
+		return &AWSAgentCoreConfig{
+			Region:          "us-east-1",
+			FoundationModel: MapModelToBedrock(ModelSonnet),
+			IAC:             "cdk",
+			LambdaRuntime:   "python3.11",
+		}
+	case PlatformKubernetes, PlatformAWSEKS, PlatformAzureAKS, PlatformGCPGKE:
+		return &KubernetesConfig{Namespace: "default"}
+	case PlatformDockerCompose:
+		return &DockerComposeConfig{NetworkMode: "bridge"}
+	case PlatformAgentKitLocal:
+		return &AgentKitLocalConfig{Transport: "stdio"}
+	default:
+		return nil
+	}
+}
+
 // DeploymentMode represents the deployment execution mode.
 type DeploymentMode string
 
@@ -38,6 +104,50 @@ const (
 	PriorityP3 Priority = "p3"
 )
 
+// priorities lists every Priority level.
+var priorities = []Priority{PriorityP1, PriorityP2, PriorityP3}
+
+// Valid reports whether p is a known priority level. Priorities are
+// case-sensitive; use ParsePriority to normalize user input.
+func (p Priority) Valid() bool {
+	for _, known := range priorities {
+		if p == known {
+			return true
+		}
+	}
+	return false
+}
+
+// rank returns the position of p in priorities, or len(priorities) for an
+// unset or unknown priority, so lower ranks deploy first.
+func (p Priority) rank() int {
+	for i, known := range priorities {
+		if p == known {
+			return i
+		}
+	}
+	return len(priorities)
+}
+
+// ParsePriority parses s as a priority level, ignoring case and surrounding
+// whitespace (e.g., "P1" parses as PriorityP1).
+func ParsePriority(s string) (Priority, error) {
+	p := Priority(strings.ToLower(strings.TrimSpace(s)))
+	if !p.Valid() {
+		return "", fmt.Errorf("unknown priority %q (allowed: %s)", s, allowedPriorities())
+	}
+	return p, nil
+}
+
+// allowedPriorities returns the known priority levels as a comma-separated list.
+func allowedPriorities() string {
+	names := make([]string, len(priorities))
+	for i, p := range priorities {
+		names[i] = string(p)
+	}
+	return strings.Join(names, ", ")
+}
+
 // Target represents a deployment target definition.
 type Target struct {
 	// Name is the unique name for this deployment target.
@@ -55,9 +165,20 @@ type Target struct {
 	// Output is the directory for generated deployment artifacts.
 	Output string `json:"output,omitempty"`
 
+	// Labels are metadata about the target (e.g., owner, cost-center), which
+	// generators may propagate as resource tags.
+	Labels map[string]string `json:"labels,omitempty"`
+
 	// Runtime is the runtime configuration for workflow execution.
 	Runtime *RuntimeConfig `json:"runtime,omitempty"`
 
+	// ConfigFile is the path of a JSON file holding the platform-specific
+	// configuration, as an alternative to setting it inline. See
+	// LoadConfigFile. Setting both is an error; setting neither is allowed
+	// because the target then uses the platform's DefaultConfig (see
+	// Deployment.FillDefaults).
+	ConfigFile string `json:"configFile,omitempty"`
+
 	// Platform-specific configurations (use the one matching Platform field)
 	ClaudeCode    *ClaudeCodeConfig    `json:"claudeCode,omitempty"`
 	GeminiCLI     *GeminiCLIConfig     `json:"geminiCli,omitempty"`
@@ -178,6 +299,419 @@ func (d *Deployment) AddTarget(target Target) *Deployment {
 	return d
 }
 
+// Validate checks that the target is well-formed.
+func (t *Target) Validate() error {
+	var errs ValidationErrors
+	if t.Name == "" {
+		errs.addf("name", "is required")
+	}
+	switch {
+	case t.Platform == "":
+		errs.addf("platform", "is required")
+	case !t.Platform.Valid():
+		errs.addf("platform", "unknown platform %q", t.Platform)
+	}
+	if t.Priority != "" && !t.Priority.Valid() {
+		errs.addf("priority", "unknown priority %q (allowed: %s)", t.Priority, allowedPriorities())
+	}
+	if t.ConfigFile != "" && t.hasPlatformConfig() {
+		errs.addf("configFile", "cannot be combined with an inline %s config", t.Platform)
+	}
+	if t.Kubernetes != nil {
+		errs.add("kubernetes", t.Kubernetes.Validate())
+	}
+	return errs.err()
+}
+
+// LoadConfigFile reads the JSON file named by ConfigFile from fsys into the
+// platform-specific config field matching Platform, then clears ConfigFile so
+// the target carries the config inline. Unknown fields in the file are
+// rejected. It does nothing if ConfigFile is empty.
+func (t *Target) LoadConfigFile(fsys fs.FS) error {
+	if t.ConfigFile == "" {
+		return nil
+	}
+	if t.hasPlatformConfig() {
+		return fmt.Errorf("target %s: configFile cannot be combined with an inline %s config", t.Name, t.Platform)
+	}
+	def := t.Platform.DefaultConfig()
+	if def == nil {
+		return fmt.Errorf("target %s: unknown platform %q", t.Name, t.Platform)
+	}
+
+	data, err := fs.ReadFile(fsys, fsPath(t.ConfigFile))
+	if err != nil {
+		return fmt.Errorf("target %s: read config file: %w", t.Name, err)
+	}
+	cfg := reflect.New(reflect.TypeOf(def).Elem()).Interface()
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(cfg); err != nil {
+		return fmt.Errorf("target %s: parse config file %s: %w", t.Name, t.ConfigFile, err)
+	}
+
+	t.setPlatformConfig(cfg)
+	t.ConfigFile = ""
+	return nil
+}
+
+// FillDefaults sets the platform-specific configuration of every target that
+// has none to the platform's DefaultConfig. When a target sets Output, the
+// default agent or plugin directory follows it. Targets that already carry a
+// config, or that name a ConfigFile to be loaded later, are left unchanged. It returns ValidationErrors for targets with an
+// unknown platform, after filling the others.
+func (d *Deployment) FillDefaults() error {
+	var errs ValidationErrors
+	for i := range d.Targets {
+		t := &d.Targets[i]
+		if t.hasPlatformConfig() || t.ConfigFile != "" {
+			continue
+		}
+		cfg := t.Platform.DefaultConfig()
+		if cfg == nil {
+			errs.addf(fmt.Sprintf("targets[%d].platform", i), "unknown platform %q", t.Platform)
+			continue
+		}
+		if t.Output != "" {
+			switch c := cfg.(type) {
+			case *ClaudeCodeConfig:
+				c.AgentDir = t.Output
+			case *KiroCLIConfig:
+				c.PluginDir = t.Output
+			}
+		}
+		t.setPlatformConfig(cfg)
+	}
+	return errs.err()
+}
+
+// hasPlatformConfig reports whether the config field for the target's
+// platform is set.
+func (t *Target) hasPlatformConfig() bool {
+	switch t.Platform {
+	case PlatformClaudeCode:
+		return t.ClaudeCode != nil
+	case PlatformGeminiCLI:
+		return t.GeminiCLI != nil
+	case PlatformKiroCLI:
+		return t.KiroCLI != nil
+	case PlatformADKGo:
+		return t.ADKGo != nil
+	case PlatformCrewAI:
+		return t.CrewAI != nil
+	case PlatformAutoGen:
+		return t.AutoGen != nil
+	case PlatformAWSAgentCore:
+		return t.AWSAgentCore != nil
+	case PlatformKubernetes, PlatformAWSEKS, PlatformAzureAKS, PlatformGCPGKE:
+		return t.Kubernetes != nil
+	case PlatformDockerCompose:
+		return t.DockerCompose != nil
+	case PlatformAgentKitLocal:
+		return t.AgentKitLocal != nil
+	default:
+		return false
+	}
+}
+
+// setPlatformConfig stores cfg, a pointer of one of the types returned by
+// Platform.DefaultConfig, in the matching config field.
+func (t *Target) setPlatformConfig(cfg interface{}) {
+	switch c := cfg.(type) {
+	case *ClaudeCodeConfig:
+		t.ClaudeCode = c
+	case *GeminiCLIConfig:
+		t.GeminiCLI = c
+	case *KiroCLIConfig:
+		t.KiroCLI = c
+	case *ADKGoConfig:
+		t.ADKGo = c
+	case *CrewAIConfig:
+		t.CrewAI = c
+	case *AutoGenConfig:
+		t.AutoGen = c
+	case *AWSAgentCoreConfig:
+		t.AWSAgentCore = c
+	case *KubernetesConfig:
+		t.Kubernetes = c
+	case *DockerComposeConfig:
+		t.DockerCompose = c
+	case *AgentKitLocalConfig:
+		t.AgentKitLocal = c
+	}
+}
+
+// Validate checks that the deployment and all of its targets are well-formed.
+// Target names must be unique.
+func (d *Deployment) Validate() error {
+	var errs ValidationErrors
+	if d.Team == "" {
+		errs.addf("team", "is required")
+	}
+	seen := make(map[string]bool)
+	for i := range d.Targets {
+		t := &d.Targets[i]
+		path := fmt.Sprintf("targets[%d]", i)
+		if t.Name != "" && seen[t.Name] {
+			errs.addf(path+".name", "duplicate target name %q", t.Name)
+		}
+		seen[t.Name] = true
+		errs.add(path, t.Validate())
+	}
+	return errs.err()
+}
+
+// ValidateOutputIsolation checks that targets on different platforms do not
+// share an Output directory, where their artifacts could collide. Paths are
+// compared after cleaning; targets without an Output and targets on the same
+// platform are not checked. It returns ValidationErrors naming each target
+// whose Output was already claimed by another platform.
+func (d *Deployment) ValidateOutputIsolation() error {
+	owners := make(map[string]*Target)
+	var errs ValidationErrors
+	for i := range d.Targets {
+		t := &d.Targets[i]
+		if t.Output == "" {
+			continue
+		}
+		dir := path.Clean(t.Output)
+		owner, ok := owners[dir]
+		if !ok {
+			owners[dir] = t
+			continue
+		}
+		if owner.Platform != t.Platform {
+			errs.addf(fmt.Sprintf("targets[%d].output", i), "%q is also the output of target %s (%s); use a distinct directory for %s",
+				t.Output, owner.Name, owner.Platform, t.Platform)
+		}
+	}
+	return errs.err()
+}
+
+// DeploymentBuilder builds a Deployment from typed platform helpers.
+type DeploymentBuilder struct {
+	deployment *Deployment
+}
+
+// NewDeploymentBuilder creates a DeploymentBuilder for the given team.
+func NewDeploymentBuilder(team string) *DeploymentBuilder {
+	return &DeploymentBuilder{deployment: NewDeployment(team)}
+}
+
+// AddTarget adds an arbitrary target and returns the builder for chaining.
+func (b *DeploymentBuilder) AddTarget(target Target) *DeploymentBuilder {
+	b.deployment.AddTarget(target)
+	return b
+}
+
+// AddClaudeCode adds a Claude Code target writing markdown agents to agentDir.
+func (b *DeploymentBuilder) AddClaudeCode(name, agentDir string) *DeploymentBuilder {
+	return b.AddTarget(Target{
+		Name:     name,
+		Platform: PlatformClaudeCode,
+		Output:   agentDir,
+		ClaudeCode: &ClaudeCodeConfig{
+			AgentDir: agentDir,
+			Format:   "markdown",
+		},
+	})
+}
+
+// AddKubernetes adds a Kubernetes target with the given configuration.
+func (b *DeploymentBuilder) AddKubernetes(name string, cfg KubernetesConfig) *DeploymentBuilder {
+	return b.AddTarget(Target{
+		Name:       name,
+		Platform:   PlatformKubernetes,
+		Kubernetes: &cfg,
+	})
+}
+
+// AddAgentCore adds an AWS AgentCore target with the given configuration.
+func (b *DeploymentBuilder) AddAgentCore(name string, cfg AWSAgentCoreConfig) *DeploymentBuilder {
+	return b.AddTarget(Target{
+		Name:         name,
+		Platform:     PlatformAWSAgentCore,
+		AWSAgentCore: &cfg,
+	})
+}
+
+// Build validates and returns the deployment.
+func (b *DeploymentBuilder) Build() (*Deployment, error) {
+	if err := b.deployment.Validate(); err != nil {
+		return nil, err
+	}
+	return b.deployment, nil
+}
+
+// DistinctPlatformTargets returns the first target for each unique Platform,
+// in declaration order.
+func (d *Deployment) DistinctPlatformTargets() []Target {
+	seen := make(map[Platform]bool)
+	var targets []Target
+	for _, t := range d.Targets {
+		if seen[t.Platform] {
+			continue
+		}
+		seen[t.Platform] = true
+		targets = append(targets, t)
+	}
+	return targets
+}
+
+// CoverageReport compares the deployment's target platforms with
+// requiredPlatforms. Missing lists required platforms with no target; extra
+// lists targeted platforms that are not required. Both keep the order of
+// first appearance and contain no duplicates.
+func (d *Deployment) CoverageReport(requiredPlatforms []Platform) (missing, extra []Platform) {
+	required := make(map[Platform]bool, len(requiredPlatforms))
+	for _, p := range requiredPlatforms {
+		required[p] = true
+	}
+	targeted := make(map[Platform]bool, len(d.Targets))
+	for _, t := range d.DistinctPlatformTargets() {
+		targeted[t.Platform] = true
+		if !required[t.Platform] {
+			extra = append(extra, t.Platform)
+		}
+	}
+	seen := make(map[Platform]bool, len(requiredPlatforms))
+	for _, p := range requiredPlatforms {
+		if !targeted[p] && !seen[p] {
+			missing = append(missing, p)
+		}
+		seen[p] = true
+	}
+	return missing, extra
+}
+
+// RequiredBinaries returns the sorted, deduplicated union of the Requires
+// entries of agents, which should be the resolved agent definitions of the
+// deployment's team. Every target of the deployment runs the same agents, so
+// the result is the set of binaries a shared runtime image must provide.
+func (d *Deployment) RequiredBinaries(agents []*Agent) []string {
+	seen := make(map[string]bool)
+	var binaries []string
+	for _, agent := range agents {
+		for _, bin := range agent.Requires {
+			if bin == "" || seen[bin] {
+				continue
+			}
+			seen[bin] = true
+			binaries = append(binaries, bin)
+		}
+	}
+	sort.Strings(binaries)
+	return binaries
+}
+
+// OrderedTargets returns a copy of the targets sorted by Priority (p1, p2,
+// p3, then unset or unknown) and then by Name, giving a deterministic
+// deployment order regardless of declaration order.
+func (d *Deployment) OrderedTargets() []Target {
+	targets := append([]Target(nil), d.Targets...)
+	sort.SliceStable(targets, func(i, j int) bool {
+		ri, rj := targets[i].Priority.rank(), targets[j].Priority.rank()
+		if ri != rj {
+			return ri < rj
+		}
+		return targets[i].Name < targets[j].Name
+	})
+	return targets
+}
+
+// TargetsWithLabel returns the targets whose Labels map key to value, in
+// declaration order.
+func (d *Deployment) TargetsWithLabel(key, value string) []Target {
+	var targets []Target
+	for _, t := range d.Targets {
+		if v, ok := t.Labels[key]; ok && v == value {
+			targets = append(targets, t)
+		}
+	}
+	return targets
+}
+
+// ResolveTeam returns the team named by d.Team from teams. If it is absent,
+// the error suggests the closest team name when one is within a small edit
+// distance.
+func (d *Deployment) ResolveTeam(teams map[string]*Team) (*Team, error) {
+	if d.Team == "" {
+		return nil, fmt.Errorf("deployment team is required")
+	}
+	if team, ok := teams[d.Team]; ok && team != nil {
+		return team, nil
+	}
+
+	names := make([]string, 0, len(teams))
+	for name := range teams {
+		names = append(names, name)
+	}
+	if suggestion := closestName(d.Team, names); suggestion != "" {
+		return nil, fmt.Errorf("unknown team %q (did you mean %q?)", d.Team, suggestion)
+	}
+	return nil, fmt.Errorf("unknown team %q", d.Team)
+}
+
+// closestName returns the candidate with the smallest edit distance to name,
+// provided the distance is at most a third of name's length (and at least 1).
+// Ties are broken alphabetically. It returns "" if no candidate is close.
+func closestName(name string, candidates []string) string {
+	sort.Strings(candidates)
+	limit := len(name) / 3
+	if limit < 1 {
+		limit = 1
+	}
+	best, bestDist := "", limit+1
+	for _, c := range candidates {
+		if d := editDistance(strings.ToLower(name), strings.ToLower(c)); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// AffectedTargets returns the targets that must be regenerated when the named
+// agents change. Every target deploys the whole team, so this is all targets
+// when team is the deployment's team and lists any changed agent, and nil
+// otherwise. Agent names are matched exactly against team.Agents.
+func (d *Deployment) AffectedTargets(changedAgents []string, team *Team) []Target {
+	if team == nil || team.Name != d.Team {
+		return nil
+	}
+	members := make(map[string]bool, len(team.Agents))
+	for _, name := range team.Agents {
+		members[name] = true
+	}
+	for _, name := range changedAgents {
+		if members[name] {
+			return append([]Target(nil), d.Targets...)
+		}
+	}
+	return nil
+}
+
 // ClaudeCodeConfig is the configuration for Claude Code platform.
 type ClaudeCodeConfig struct {
 	AgentDir string `json:"agentDir"`
@@ -204,6 +738,78 @@ type KubernetesConfig struct {
 	HelmChart      bool            `json:"helmChart"`
 	ImageRegistry  string          `json:"imageRegistry,omitempty"`
 	ResourceLimits *ResourceLimits `json:"resourceLimits,omitempty"`
+	Sidecars       []Container     `json:"sidecars,omitempty"`
+	Probes         *ProbeConfig    `json:"probes,omitempty"`
+}
+
+// Validate checks that the Kubernetes configuration is well-formed.
+func (c *KubernetesConfig) Validate() error {
+	var errs ValidationErrors
+	seen := make(map[string]bool)
+	for i, sc := range c.Sidecars {
+		path := fmt.Sprintf("sidecars[%d]", i)
+		if sc.Name == "" {
+			errs.addf(path+".name", "is required")
+		} else if seen[sc.Name] {
+			errs.addf(path+".name", "duplicate sidecar name %q", sc.Name)
+		}
+		seen[sc.Name] = true
+		if sc.Image == "" {
+			errs.addf(path+".image", "is required")
+		}
+		if sc.ResourceLimits != nil {
+			errs.add(path+".resourceLimits", sc.ResourceLimits.Validate())
+		}
+	}
+	if c.ResourceLimits != nil {
+		errs.add("resourceLimits", c.ResourceLimits.Validate())
+	}
+	if c.Probes != nil {
+		errs.add("probes", c.Probes.Validate())
+	}
+	errs.add("", c.ValidateImageRegistry())
+	return errs.err()
+}
+
+// imageRegistry matches a registry reference: a host with an optional port,
+// followed by optional lowercase path components (e.g., ghcr.io/acme,
+// localhost:5000, registry.example.com/team/agents).
+var imageRegistry = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9.-]*[a-zA-Z0-9])?(:[0-9]+)?(/[a-z0-9]+([._-][a-z0-9]+)*)*$`)
+
+// ValidateImageRegistry checks that ImageRegistry, if set, is a host[/path]
+// reference with no URL scheme and no trailing slash.
+func (c *KubernetesConfig) ValidateImageRegistry() error {
+	var errs ValidationErrors
+	switch r := c.ImageRegistry; {
+	case r == "":
+	case strings.Contains(r, "://"):
+		errs.addf("imageRegistry", "%q must not include a URL scheme", r)
+	case strings.HasSuffix(r, "/"):
+		errs.addf("imageRegistry", "%q must not end with a slash", r)
+	case !imageRegistry.MatchString(r):
+		errs.addf("imageRegistry", "%q is not a valid host[/path] registry reference", r)
+	}
+	return errs.err()
+}
+
+// ImageFor returns the container image reference for an agent:
+// "<registry>/<agent>:latest", or "<agent>:latest" if ImageRegistry is unset.
+// A trailing slash on ImageRegistry is ignored.
+func (c *KubernetesConfig) ImageFor(agentName string) string {
+	registry := strings.TrimRight(c.ImageRegistry, "/")
+	if registry == "" {
+		return agentName + ":latest"
+	}
+	return registry + "/" + agentName + ":latest"
+}
+
+// Container describes an additional container run in an agent's pod,
+// such as a proxy or log shipper.
+type Container struct {
+	Name           string            `json:"name"`
+	Image          string            `json:"image"`
+	Env            map[string]string `json:"env,omitempty"`
+	ResourceLimits *ResourceLimits   `json:"resourceLimits,omitempty"`
 }
 
 // ResourceLimits defines resource limits for step execution.
@@ -213,6 +819,117 @@ type ResourceLimits struct {
 	GPU    int    `json:"gpu,omitempty"`
 }
 
+var (
+	// cpuQuantity matches CPU quantities such as "2", "0.5", or "500m".
+	cpuQuantity = regexp.MustCompile(`^([0-9]+(\.[0-9]+)?|\.[0-9]+)m?$`)
+
+	// memoryQuantity matches memory quantities such as "512Mi" or "1G".
+	memoryQuantity = regexp.MustCompile(`^([0-9]+(\.[0-9]+)?|\.[0-9]+)([kMGTPE]|[KMGTPE]i)?$`)
+)
+
+// Validate checks that CPU and Memory use Kubernetes quantity syntax and that
+// GPU is non-negative.
+func (r *ResourceLimits) Validate() error {
+	var errs ValidationErrors
+	if r.CPU != "" && !cpuQuantity.MatchString(r.CPU) {
+		errs.addf("cpu", "invalid quantity %q (e.g., 2, 0.5, 500m)", r.CPU)
+	}
+	if r.Memory != "" && !memoryQuantity.MatchString(r.Memory) {
+		errs.addf("memory", "invalid quantity %q (e.g., 512Mi, 2Gi, 1G)", r.Memory)
+	}
+	if r.GPU < 0 {
+		errs.addf("gpu", "must be non-negative, got %d", r.GPU)
+	}
+	return errs.err()
+}
+
+// ProbeConfig configures the HTTP liveness and readiness probes of an agent
+// container. Unset fields take the values of DefaultProbeConfig.
+type ProbeConfig struct {
+	LivenessPath        string `json:"livenessPath,omitempty"`
+	ReadinessPath       string `json:"readinessPath,omitempty"`
+	Port                int    `json:"port,omitempty"`
+	InitialDelaySeconds int    `json:"initialDelaySeconds,omitempty"`
+	PeriodSeconds       int    `json:"periodSeconds,omitempty"`
+	TimeoutSeconds      int    `json:"timeoutSeconds,omitempty"`
+	FailureThreshold    int    `json:"failureThreshold,omitempty"`
+}
+
+// DefaultProbeConfig returns the probe settings used when none are configured.
+func DefaultProbeConfig() ProbeConfig {
+	return ProbeConfig{
+		LivenessPath:        "/healthz",
+		ReadinessPath:       "/readyz",
+		Port:                8080,
+		InitialDelaySeconds: 5,
+		PeriodSeconds:       10,
+		TimeoutSeconds:      1,
+		FailureThreshold:    3,
+	}
+}
+
+// WithDefaults returns a copy of the probe config with unset fields filled
+// from DefaultProbeConfig. A nil config yields the defaults.
+func (p *ProbeConfig) WithDefaults() ProbeConfig {
+	def := DefaultProbeConfig()
+	if p == nil {
+		return def
+	}
+	c := *p
+	if c.LivenessPath == "" {
+		c.LivenessPath = def.LivenessPath
+	}
+	if c.ReadinessPath == "" {
+		c.ReadinessPath = def.ReadinessPath
+	}
+	if c.Port == 0 {
+		c.Port = def.Port
+	}
+	if c.InitialDelaySeconds == 0 {
+		c.InitialDelaySeconds = def.InitialDelaySeconds
+	}
+	if c.PeriodSeconds == 0 {
+		c.PeriodSeconds = def.PeriodSeconds
+	}
+	if c.TimeoutSeconds == 0 {
+		c.TimeoutSeconds = def.TimeoutSeconds
+	}
+	if c.FailureThreshold == 0 {
+		c.FailureThreshold = def.FailureThreshold
+	}
+	return c
+}
+
+// Validate checks that probe paths are absolute, the port is in range, and
+// timings are non-negative.
+func (p *ProbeConfig) Validate() error {
+	var errs ValidationErrors
+	if p.LivenessPath != "" && !strings.HasPrefix(p.LivenessPath, "/") {
+		errs.addf("livenessPath", "must start with /, got %q", p.LivenessPath)
+	}
+	if p.ReadinessPath != "" && !strings.HasPrefix(p.ReadinessPath, "/") {
+		errs.addf("readinessPath", "must start with /, got %q", p.ReadinessPath)
+	}
+	if p.Port < 0 || p.Port > 65535 {
+		errs.addf("port", "must be between 1 and 65535, got %d", p.Port)
+	}
+	timings := []struct {
+		name  string
+		value int
+	}{
+		{"initialDelaySeconds", p.InitialDelaySeconds},
+		{"periodSeconds", p.PeriodSeconds},
+		{"timeoutSeconds", p.TimeoutSeconds},
+		{"failureThreshold", p.FailureThreshold},
+	}
+	for _, t := range timings {
+		if t.value < 0 {
+			errs.addf(t.name, "must be non-negative, got %d", t.value)
+		}
+	}
+	return errs.err()
+}
+
 // AgentKitLocalConfig is the configuration for AgentKit local platform.
 type AgentKitLocalConfig struct {
 	Transport string `json:"transport"`
@@ -1,8 +1,14 @@
 package multiagentspec
 
 import (
+	"bytes"
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 func TestPlatformConstants(t *testing.T) {
@@ -473,3 +479,422 @@ func TestTargetWithTypedConfigs(t *testing.T) {
 		t.Errorf("GeminiCLI.Model = %q, want %q", decoded.GeminiCLI.Model, "gemini-2.0-flash")
 	}
 }
+
+func TestDistinctPlatformTargets(t *testing.T) {
+	d := NewDeployment("team").
+		AddTarget(Target{Name: "claude-a", Platform: PlatformClaudeCode}).
+		AddTarget(Target{Name: "k8s-a", Platform: PlatformKubernetes}).
+		AddTarget(Target{Name: "claude-b", Platform: PlatformClaudeCode}).
+		AddTarget(Target{Name: "k8s-b", Platform: PlatformKubernetes}).
+		AddTarget(Target{Name: "kiro", Platform: PlatformKiroCLI})
+
+	got := d.DistinctPlatformTargets()
+	want := []string{"claude-a", "k8s-a", "kiro"}
+	if len(got) != len(want) {
+		t.Fatalf("len(DistinctPlatformTargets()) = %d, want %d", len(got), len(want))
+	}
+	for i, name := range want {
+		if got[i].Name != name {
+			t.Errorf("DistinctPlatformTargets()[%d].Name = %q, want %q", i, got[i].Name, name)
+		}
+	}
+
+	if got := NewDeployment("team").DistinctPlatformTargets(); len(got) != 0 {
+		t.Errorf("empty deployment returned %d targets", len(got))
+	}
+}
+
+func TestPlatformValid(t *testing.T) {
+	if !PlatformAgentKitLocal.Valid() {
+		t.Error("PlatformAgentKitLocal should be valid")
+	}
+	if Platform("mainframe").Valid() {
+		t.Error("unknown platform should be invalid")
+	}
+}
+
+func TestPriorityValid(t *testing.T) {
+	if !PriorityP2.Valid() {
+		t.Error("PriorityP2 should be valid")
+	}
+	for _, p := range []Priority{"P1", "high", ""} {
+		if p.Valid() {
+			t.Errorf("Priority(%q) should be invalid", p)
+		}
+	}
+}
+
+func TestParsePriority(t *testing.T) {
+	for _, s := range []string{"p1", "P1", " p1 "} {
+		if got, err := ParsePriority(s); err != nil || got != PriorityP1 {
+			t.Errorf("ParsePriority(%q) = %q, %v; want p1", s, got, err)
+		}
+	}
+	_, err := ParsePriority("high")
+	if err == nil || !strings.Contains(err.Error(), "allowed: p1, p2, p3") {
+		t.Errorf("ParsePriority(high) error = %v", err)
+	}
+}
+
+func TestTargetValidatePriority(t *testing.T) {
+	target := Target{Name: "local", Platform: PlatformClaudeCode, Priority: "P1"}
+	err := target.Validate()
+	if err == nil || err.Error() != `priority: unknown priority "P1" (allowed: p1, p2, p3)` {
+		t.Errorf("Validate() = %v", err)
+	}
+	target.Priority = PriorityP1
+	if err := target.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+}
+
+func TestDeploymentValidate(t *testing.T) {
+	d := NewDeployment("team").
+		AddTarget(Target{Name: "a", Platform: PlatformClaudeCode}).
+		AddTarget(Target{Name: "a", Platform: "mainframe"}).
+		AddTarget(Target{})
+
+	err := d.Validate()
+	if err == nil {
+		t.Fatal("Validate() = nil, want error")
+	}
+	for _, want := range []string{
+		`targets[1].name: duplicate target name "a"`,
+		`targets[1].platform: unknown platform "mainframe"`,
+		"targets[2].name: is required",
+		"targets[2].platform: is required",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %q, want it to contain %q", err, want)
+		}
+	}
+
+	if err := (&Deployment{}).Validate(); err == nil || !strings.Contains(err.Error(), "team: is required") {
+		t.Errorf("Validate() = %v, want team error", err)
+	}
+}
+
+func TestDeploymentBuilder(t *testing.T) {
+	d, err := NewDeploymentBuilder("stats-team").
+		AddClaudeCode("local", ".claude/agents").
+		AddKubernetes("prod", KubernetesConfig{Namespace: "agents"}).
+		AddAgentCore("aws", AWSAgentCoreConfig{Region: "us-east-1"}).
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	if len(d.Targets) != 3 {
+		t.Fatalf("len(Targets) = %d, want 3", len(d.Targets))
+	}
+	if d.Targets[0].ClaudeCode == nil || d.Targets[0].ClaudeCode.AgentDir != ".claude/agents" {
+		t.Errorf("ClaudeCode = %+v", d.Targets[0].ClaudeCode)
+	}
+	if d.Targets[1].Platform != PlatformKubernetes || d.Targets[1].Kubernetes.Namespace != "agents" {
+		t.Errorf("Kubernetes target = %+v", d.Targets[1])
+	}
+	if d.Targets[2].AWSAgentCore == nil || d.Targets[2].AWSAgentCore.Region != "us-east-1" {
+		t.Errorf("AWSAgentCore = %+v", d.Targets[2].AWSAgentCore)
+	}
+
+	_, err = NewDeploymentBuilder("t").
+		AddClaudeCode("dup", "a").
+		AddClaudeCode("dup", "b").
+		Build()
+	if err == nil {
+		t.Error("Build should fail for duplicate target names")
+	}
+}
+
+func TestResourceLimitsValidate(t *testing.T) {
+	valid := []ResourceLimits{
+		{},
+		{CPU: "2", Memory: "512Mi"},
+		{CPU: "500m", Memory: "1G", GPU: 1},
+		{CPU: "0.5", Memory: "1.5Gi"},
+	}
+	for _, r := range valid {
+		if err := r.Validate(); err != nil {
+			t.Errorf("Validate(%+v) = %v, want nil", r, err)
+		}
+	}
+
+	invalid := ResourceLimits{CPU: "half", Memory: "512MB", GPU: -1}
+	err := invalid.Validate()
+	if err == nil {
+		t.Fatal("Validate() = nil, want error")
+	}
+	for _, want := range []string{"cpu: invalid quantity", "memory: invalid quantity", "gpu: must be non-negative"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %q, missing %q", err, want)
+		}
+	}
+}
+
+func TestDeploymentRequiredBinaries(t *testing.T) {
+	research := NewAgent("research", "")
+	research.Requires = []string{"git", "curl"}
+	build := NewAgent("build", "")
+	build.Requires = []string{"go", "git"}
+
+	d := NewDeployment("t")
+	got := d.RequiredBinaries([]*Agent{research, build, NewAgent("plain", "")})
+	if strings.Join(got, ",") != "curl,git,go" {
+		t.Errorf("RequiredBinaries() = %v, want [curl git go]", got)
+	}
+	if got := d.RequiredBinaries(nil); got != nil {
+		t.Errorf("RequiredBinaries(nil) = %v, want nil", got)
+	}
+}
+
+func TestDeploymentCoverageReport(t *testing.T) {
+	d := NewDeployment("t").
+		AddTarget(Target{Name: "local", Platform: PlatformClaudeCode}).
+		AddTarget(Target{Name: "kiro", Platform: PlatformKiroCLI}).
+		AddTarget(Target{Name: "local-2", Platform: PlatformClaudeCode})
+
+	missing, extra := d.CoverageReport([]Platform{PlatformClaudeCode, PlatformKubernetes, PlatformKubernetes})
+	if len(missing) != 1 || missing[0] != PlatformKubernetes {
+		t.Errorf("missing = %v, want [kubernetes]", missing)
+	}
+	if len(extra) != 1 || extra[0] != PlatformKiroCLI {
+		t.Errorf("extra = %v, want [kiro-cli]", extra)
+	}
+
+	missing, extra = d.CoverageReport([]Platform{PlatformClaudeCode, PlatformKiroCLI})
+	if missing != nil || extra != nil {
+		t.Errorf("CoverageReport() = %v, %v; want full coverage", missing, extra)
+	}
+}
+
+func TestPlatformDefaultConfig(t *testing.T) {
+	for _, p := range platforms {
+		if p.DefaultConfig() == nil {
+			t.Errorf("%s has no default config", p)
+		}
+	}
+	if Platform("mainframe").DefaultConfig() != nil {
+		t.Error("unknown platform should have no default config")
+	}
+	if cfg, ok := PlatformAWSEKS.DefaultConfig().(*KubernetesConfig); !ok || cfg.Namespace != "default" {
+		t.Errorf("aws-eks DefaultConfig() = %#v", PlatformAWSEKS.DefaultConfig())
+	}
+}
+
+func TestDeploymentFillDefaults(t *testing.T) {
+	custom := &KubernetesConfig{Namespace: "agents"}
+	d := NewDeployment("t").
+		AddTarget(Target{Name: "local", Platform: PlatformClaudeCode, Output: "out/agents"}).
+		AddTarget(Target{Name: "k8s", Platform: PlatformKubernetes, Kubernetes: custom}).
+		AddTarget(Target{Name: "agentkit", Platform: PlatformAgentKitLocal}).
+		AddTarget(Target{Name: "legacy", Platform: "mainframe"})
+
+	err := d.FillDefaults()
+	if err == nil || err.Error() != `targets[3].platform: unknown platform "mainframe"` {
+		t.Errorf("FillDefaults() = %v", err)
+	}
+
+	if cc := d.Targets[0].ClaudeCode; cc == nil || cc.AgentDir != "out/agents" || cc.Format != "markdown" {
+		t.Errorf("ClaudeCode = %+v", cc)
+	}
+	if d.Targets[1].Kubernetes != custom {
+		t.Error("existing config was replaced")
+	}
+	if ak := d.Targets[2].AgentKitLocal; ak == nil || ak.Transport != "stdio" {
+		t.Errorf("AgentKitLocal = %+v", ak)
+	}
+}
+
+func TestDeploymentFillDefaultsSkipsConfigFile(t *testing.T) {
+	d := NewDeployment("t").
+		AddTarget(Target{Name: "eks", Platform: PlatformAWSEKS, ConfigFile: "configs/eks.json"})
+	if err := d.FillDefaults(); err != nil {
+		t.Fatalf("FillDefaults() = %v", err)
+	}
+	if d.Targets[0].Kubernetes != nil {
+		t.Errorf("Kubernetes = %+v, want nil until the config file is loaded", d.Targets[0].Kubernetes)
+	}
+	if err := d.Targets[0].Validate(); err != nil {
+		t.Errorf("Validate() after FillDefaults = %v", err)
+	}
+}
+
+func TestDeploymentSchemaMatchesPublished(t *testing.T) {
+	published, err := os.ReadFile(filepath.Join("..", "..", "schema", "deployment", "deployment.schema.json"))
+	if err != nil {
+		t.Fatalf("read published schema: %v", err)
+	}
+	if got := DeploymentSchema(); !bytes.Equal(got, published) {
+		t.Error("schema/deployment/deployment.schema.json is out of date; run `go run .` in tools/generate")
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(DeploymentSchema(), &doc); err != nil {
+		t.Fatalf("DeploymentSchema() is not valid JSON: %v", err)
+	}
+	if doc["$id"] != DeploymentSchemaID {
+		t.Errorf("$id = %v", doc["$id"])
+	}
+}
+
+func TestDeploymentAffectedTargets(t *testing.T) {
+	team := NewTeam("stats", "1.0.0").WithAgents("lead", "research")
+	d := NewDeployment("stats").
+		AddTarget(Target{Name: "local", Platform: PlatformClaudeCode}).
+		AddTarget(Target{Name: "eks", Platform: PlatformAWSEKS})
+
+	got := d.AffectedTargets([]string{"writer", "research"}, team)
+	if len(got) != 2 || got[0].Name != "local" || got[1].Name != "eks" {
+		t.Errorf("AffectedTargets() = %+v, want all targets", got)
+	}
+	if got := d.AffectedTargets([]string{"writer"}, team); got != nil {
+		t.Errorf("AffectedTargets() = %+v, want nil for unrelated agent", got)
+	}
+	other := NewTeam("other", "1.0.0").WithAgents("research")
+	if got := d.AffectedTargets([]string{"research"}, other); got != nil {
+		t.Errorf("AffectedTargets() = %+v, want nil for a different team", got)
+	}
+}
+
+func TestDeploymentResolveTeam(t *testing.T) {
+	stats := NewTeam("stats-team", "1.0.0")
+	teams := map[string]*Team{"stats-team": stats, "release-team": NewTeam("release-team", "1.0.0")}
+
+	got, err := NewDeployment("stats-team").ResolveTeam(teams)
+	if err != nil || got != stats {
+		t.Errorf("ResolveTeam() = %v, %v", got, err)
+	}
+
+	_, err = NewDeployment("stat-team").ResolveTeam(teams)
+	if err == nil || err.Error() != `unknown team "stat-team" (did you mean "stats-team"?)` {
+		t.Errorf("ResolveTeam() error = %v", err)
+	}
+	_, err = NewDeployment("billing").ResolveTeam(teams)
+	if err == nil || err.Error() != `unknown team "billing"` {
+		t.Errorf("ResolveTeam() error = %v", err)
+	}
+	if _, err := NewDeployment("").ResolveTeam(teams); err == nil {
+		t.Error("ResolveTeam() should fail without a team name")
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"team", "taem", 2},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestTargetLoadConfigFile(t *testing.T) {
+	fsys := fstest.MapFS{
+		"configs/eks.json": {Data: []byte(`{"namespace": "agents", "imageRegistry": "ghcr.io/acme"}`)},
+		"configs/bad.json": {Data: []byte(`{"namespce": "agents"}`)},
+	}
+
+	target := Target{Name: "eks", Platform: PlatformAWSEKS, ConfigFile: "./configs/eks.json"}
+	if err := target.LoadConfigFile(fsys); err != nil {
+		t.Fatalf("LoadConfigFile failed: %v", err)
+	}
+	if target.Kubernetes == nil || target.Kubernetes.Namespace != "agents" || target.Kubernetes.ImageRegistry != "ghcr.io/acme" {
+		t.Errorf("Kubernetes = %+v", target.Kubernetes)
+	}
+	if target.ConfigFile != "" {
+		t.Errorf("ConfigFile = %q, want cleared", target.ConfigFile)
+	}
+	if err := target.Validate(); err != nil {
+		t.Errorf("Validate() after load = %v", err)
+	}
+
+	bad := Target{Name: "eks", Platform: PlatformAWSEKS, ConfigFile: "configs/bad.json"}
+	if err := bad.LoadConfigFile(fsys); err == nil || !strings.Contains(err.Error(), "namespce") {
+		t.Errorf("LoadConfigFile error = %v, want unknown field error", err)
+	}
+	missing := Target{Name: "eks", Platform: PlatformAWSEKS, ConfigFile: "configs/none.json"}
+	if err := missing.LoadConfigFile(fsys); err == nil {
+		t.Error("LoadConfigFile should fail for a missing file")
+	}
+}
+
+func TestTargetValidateConfigFileAndInline(t *testing.T) {
+	target := Target{
+		Name:       "local",
+		Platform:   PlatformClaudeCode,
+		ConfigFile: "claude.json",
+		ClaudeCode: &ClaudeCodeConfig{AgentDir: ".claude/agents"},
+	}
+	err := target.Validate()
+	if err == nil || !strings.Contains(err.Error(), "configFile: cannot be combined with an inline claude-code config") {
+		t.Errorf("Validate() = %v", err)
+	}
+	if err := target.LoadConfigFile(fstest.MapFS{}); err == nil {
+		t.Error("LoadConfigFile should refuse to overwrite an inline config")
+	}
+}
+
+func TestDeploymentTargetsWithLabel(t *testing.T) {
+	d := NewDeployment("t").
+		AddTarget(Target{Name: "local", Platform: PlatformClaudeCode, Labels: map[string]string{"owner": "dx"}}).
+		AddTarget(Target{Name: "eks", Platform: PlatformAWSEKS, Labels: map[string]string{"owner": "data", "cost-center": "42"}}).
+		AddTarget(Target{Name: "gke", Platform: PlatformGCPGKE, Labels: map[string]string{"owner": "data"}}).
+		AddTarget(Target{Name: "kiro", Platform: PlatformKiroCLI})
+
+	got := d.TargetsWithLabel("owner", "data")
+	if len(got) != 2 || got[0].Name != "eks" || got[1].Name != "gke" {
+		t.Errorf("TargetsWithLabel(owner, data) = %+v", got)
+	}
+	if got := d.TargetsWithLabel("cost-center", ""); got != nil {
+		t.Errorf("TargetsWithLabel(cost-center, \"\") = %+v, want nil", got)
+	}
+}
+
+func TestDeploymentOrderedTargets(t *testing.T) {
+	d := NewDeployment("t").
+		AddTarget(Target{Name: "zeta", Platform: PlatformClaudeCode}).
+		AddTarget(Target{Name: "gke", Platform: PlatformGCPGKE, Priority: PriorityP2}).
+		AddTarget(Target{Name: "eks", Platform: PlatformAWSEKS, Priority: PriorityP1}).
+		AddTarget(Target{Name: "aks", Platform: PlatformAzureAKS, Priority: PriorityP2}).
+		AddTarget(Target{Name: "alpha", Platform: PlatformKiroCLI}).
+		AddTarget(Target{Name: "kiro", Platform: PlatformKiroCLI, Priority: PriorityP3})
+
+	var got []string
+	for _, target := range d.OrderedTargets() {
+		got = append(got, target.Name)
+	}
+	want := []string{"eks", "aks", "gke", "kiro", "alpha", "zeta"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("OrderedTargets() = %v, want %v", got, want)
+	}
+	if d.Targets[0].Name != "zeta" {
+		t.Errorf("OrderedTargets() reordered d.Targets: %v", d.Targets[0].Name)
+	}
+}
+
+func TestDeploymentValidateOutputIsolation(t *testing.T) {
+	d := NewDeployment("t").
+		AddTarget(Target{Name: "local", Platform: PlatformClaudeCode, Output: "dist/"}).
+		AddTarget(Target{Name: "local-2", Platform: PlatformClaudeCode, Output: "dist"}).
+		AddTarget(Target{Name: "k8s", Platform: PlatformKubernetes, Output: "./dist"}).
+		AddTarget(Target{Name: "eks", Platform: PlatformAWSEKS, Output: "deploy/eks"}).
+		AddTarget(Target{Name: "kiro", Platform: PlatformKiroCLI})
+
+	err := d.ValidateOutputIsolation()
+	want := `targets[2].output: "./dist" is also the output of target local (claude-code); use a distinct directory for kubernetes`
+	if err == nil || err.Error() != want {
+		t.Errorf("ValidateOutputIsolation() = %v, want %q", err, want)
+	}
+
+	d.Targets[2].Output = "deploy/k8s"
+	if err := d.ValidateOutputIsolation(); err != nil {
+		t.Errorf("ValidateOutputIsolation() = %v, want nil", err)
+	}
+}
@@ -0,0 +1,84 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToDOTWithAgents renders the team as a Graphviz DOT digraph. Each team agent
+// is a node labeled with its model and tools, with dashed edges to the agents
+// it depends on. The workflow, if any, is drawn as a "workflow" cluster whose
+// step nodes are linked by depends_on edges and by dotted edges to the agents
+// that run them. Agents are resolved through registry; it returns an error if
+// any team agent is not registered.
+func (t *Team) ToDOTWithAgents(registry *AgentRegistry) (string, error) {
+	agents, err := registry.Resolve(t.Agents)
+	if err != nil {
+		return "", fmt.Errorf("team %s: %w", t.Name, err)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(t.Name))
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+
+	members := make(map[string]bool, len(t.Agents))
+	for _, name := range t.Agents {
+		members[name] = true
+	}
+
+	for i, agent := range agents {
+		label := t.Agents[i] + "\nmodel: " + string(agent.effectiveModel())
+		if tools := agent.AllTools(); len(tools) > 0 {
+			label += "\ntools: " + strings.Join(tools, ", ")
+		}
+		fmt.Fprintf(&b, "  %s [label=%s];\n", dotQuote("agent:"+t.Agents[i]), dotQuote(label))
+	}
+
+	external := make(map[string]bool)
+	for i, agent := range agents {
+		for _, dep := range agent.Dependencies {
+			name := dependencyName(dep)
+			if !members[name] && !external[name] {
+				external[name] = true
+				fmt.Fprintf(&b, "  %s [label=%s, style=dashed];\n", dotQuote("agent:"+name), dotQuote(name))
+			}
+			fmt.Fprintf(&b, "  %s -> %s [style=dashed, label=\"depends on\"];\n",
+				dotQuote("agent:"+t.Agents[i]), dotQuote("agent:"+name))
+		}
+	}
+
+	if t.Workflow != nil && len(t.Workflow.Steps) > 0 {
+		b.WriteString("  subgraph cluster_workflow {\n")
+		label := "workflow"
+		if t.Workflow.Type != "" {
+			label += " (" + string(t.Workflow.Type) + ")"
+		}
+		fmt.Fprintf(&b, "    label=%s;\n", dotQuote(label))
+		for _, step := range t.Workflow.Steps {
+			fmt.Fprintf(&b, "    %s [label=%s, shape=ellipse];\n", dotQuote("step:"+step.Name), dotQuote(step.Name))
+		}
+		for _, step := range t.Workflow.Steps {
+			for _, dep := range step.DependsOn {
+				fmt.Fprintf(&b, "    %s -> %s;\n", dotQuote("step:"+dep), dotQuote("step:"+step.Name))
+			}
+		}
+		b.WriteString("  }\n")
+
+		for _, step := range t.Workflow.Steps {
+			if step.Agent != "" {
+				fmt.Fprintf(&b, "  %s -> %s [style=dotted];\n", dotQuote("step:"+step.Name), dotQuote("agent:"+step.Agent))
+			}
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+// dotQuote returns s as a double-quoted DOT string, escaping backslashes and
+// quotes and turning newlines into DOT's \n line breaks.
+func dotQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
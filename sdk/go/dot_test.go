@@ -0,0 +1,58 @@
+package multiagentspec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTeamToDOTWithAgents(t *testing.T) {
+	registry := NewAgentRegistry()
+	lead := NewAgent("lead", "").WithModel(ModelOpus).WithTools("Task")
+	lead.Dependencies = []string{"research@>=1.0.0", "search-api"}
+	for _, a := range []*Agent{lead, NewAgent("research", "").WithTools("WebSearch", "Read")} {
+		if err := registry.Register(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	team := NewTeam("stats", "1.0.0").WithAgents("lead", "research").WithWorkflow(&Workflow{
+		Type: WorkflowDAG,
+		Steps: []Step{
+			{Name: "gather", Agent: "research"},
+			{Name: "report", Agent: "lead", DependsOn: []string{"gather"}},
+		},
+	})
+
+	dot, err := team.ToDOTWithAgents(registry)
+	if err != nil {
+		t.Fatalf("ToDOTWithAgents failed: %v", err)
+	}
+	for _, want := range []string{
+		`digraph "stats" {`,
+		`"agent:lead" [label="lead\nmodel: opus\ntools: Task"];`,
+		`"agent:research" [label="research\nmodel: sonnet\ntools: WebSearch, Read"];`,
+		`"agent:lead" -> "agent:research" [style=dashed, label="depends on"];`,
+		`"agent:search-api" [label="search-api", style=dashed];`,
+		"subgraph cluster_workflow {",
+		`label="workflow (dag)";`,
+		`"step:gather" -> "step:report";`,
+		`"step:report" -> "agent:lead" [style=dotted];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT missing %s:\n%s", want, dot)
+		}
+	}
+}
+
+func TestTeamToDOTWithAgentsUnknownAgent(t *testing.T) {
+	team := NewTeam("t", "1.0.0").WithAgents("ghost")
+	if _, err := team.ToDOTWithAgents(NewAgentRegistry()); err == nil || !strings.Contains(err.Error(), "ghost") {
+		t.Errorf("ToDOTWithAgents error = %v", err)
+	}
+}
+
+func TestDOTQuote(t *testing.T) {
+	if got := dotQuote("a \"b\"\n\\c"); got != `"a \"b\"\n\\c"` {
+		t.Errorf("dotQuote() = %s", got)
+	}
+}
@@ -0,0 +1,97 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Generator produces the deployment artifacts for one target. The result maps
+// file paths, relative to the target's Output directory, to file contents.
+type Generator interface {
+	Generate(target Target, team *Team, agents []*Agent) (map[string][]byte, error)
+}
+
+// GeneratorFunc adapts an ordinary function to the Generator interface.
+type GeneratorFunc func(target Target, team *Team, agents []*Agent) (map[string][]byte, error)
+
+// Generate calls f(target, team, agents).
+func (f GeneratorFunc) Generate(target Target, team *Team, agents []*Agent) (map[string][]byte, error) {
+	return f(target, team, agents)
+}
+
+// generators holds the registered generators, starting with the built-in
+// ones. The other platforms have no built-in generator; GenerateSystemdUnits
+// has no platform and needs an ExecStart template, so it is called directly.
+var (
+	generatorsMu sync.RWMutex
+	generators   = map[Platform]Generator{
+		PlatformClaudeCode: GeneratorFunc(generateClaudeCode),
+		PlatformKubernetes: GeneratorFunc(generateKubernetes),
+		PlatformAWSEKS:     GeneratorFunc(generateKubernetes),
+		PlatformAzureAKS:   GeneratorFunc(generateKubernetes),
+		PlatformGCPGKE:     GeneratorFunc(generateKubernetes),
+	}
+)
+
+// RegisterGenerator makes g the generator for platform p, replacing any
+// generator already registered for it, including a built-in one. Claude Code
+// and the Kubernetes platforms have built-in generators. It panics if g is
+// nil.
+func RegisterGenerator(p Platform, g Generator) {
+	if g == nil {
+		panic(fmt.Sprintf("multiagentspec: RegisterGenerator: nil generator for platform %s", p))
+	}
+	generatorsMu.Lock()
+	defer generatorsMu.Unlock()
+	generators[p] = g
+}
+
+// GetGenerator returns the generator registered for platform p.
+func GetGenerator(p Platform) (Generator, bool) {
+	generatorsMu.RLock()
+	defer generatorsMu.RUnlock()
+	g, ok := generators[p]
+	return g, ok
+}
+
+// generateKubernetes is the built-in generator for the Kubernetes platforms.
+// It uses the target's Kubernetes config, or the platform default if unset,
+// and each agent's model for the target's platform (see Agent.ModelFor).
+func generateKubernetes(target Target, team *Team, agents []*Agent) (map[string][]byte, error) {
+	cfg := target.Kubernetes
+	if cfg == nil {
+		cfg = target.Platform.DefaultConfig().(*KubernetesConfig)
+	}
+	return GenerateKubernetesManifests(team, agentsForPlatform(agents, target.Platform), *cfg)
+}
+
+// generateClaudeCode is the built-in generator for Claude Code. It renders
+// the files of Team.ToClaudeCodeProject with the target's Claude Code config,
+// or the platform default if unset, but treats Output as the agent directory,
+// so subagent files are written directly under Output alongside
+// ClaudeCodeContextFile.
+func generateClaudeCode(target Target, team *Team, agents []*Agent) (map[string][]byte, error) {
+	var cfg ClaudeCodeConfig
+	if target.ClaudeCode != nil {
+		cfg = *target.ClaudeCode
+	}
+	return team.claudeCodeProject(agents, cfg, "")
+}
+
+// agentsForPlatform returns copies of agents whose Model is resolved for
+// platform p and whose ModelByPlatform is cleared, so emitters for a related
+// platform (such as the Kubernetes manifests for EKS) use the choice for p.
+// Nil agents are dropped.
+func agentsForPlatform(agents []*Agent, p Platform) []*Agent {
+	resolved := make([]*Agent, 0, len(agents))
+	for _, a := range agents {
+		if a == nil {
+			continue
+		}
+		c := *a
+		c.Model = a.ModelFor(p)
+		c.ModelByPlatform = nil
+		resolved = append(resolved, &c)
+	}
+	return resolved
+}
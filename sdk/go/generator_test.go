@@ -0,0 +1,139 @@
+package multiagentspec
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuiltinKubernetesGenerators(t *testing.T) {
+	team := NewTeam("t", "1.0.0").WithAgents("a")
+	agents := []*Agent{NewAgent("a", "")}
+
+	for _, p := range []Platform{PlatformKubernetes, PlatformAWSEKS, PlatformAzureAKS, PlatformGCPGKE} {
+		g, ok := GetGenerator(p)
+		if !ok {
+			t.Errorf("no built-in generator for %s", p)
+			continue
+		}
+		files, err := g.Generate(Target{Name: "k", Platform: p}, team, agents)
+		if err != nil {
+			t.Errorf("%s: Generate failed: %v", p, err)
+			continue
+		}
+		if _, ok := files["a-deployment.yaml"]; !ok {
+			t.Errorf("%s: files = %v, want a-deployment.yaml", p, files)
+		}
+	}
+
+	if _, ok := GetGenerator(PlatformCrewAI); ok {
+		t.Error("GetGenerator(crewai) should report no generator")
+	}
+}
+
+func TestBuiltinClaudeCodeGenerator(t *testing.T) {
+	g, ok := GetGenerator(PlatformClaudeCode)
+	if !ok {
+		t.Fatal("no built-in generator for claude-code")
+	}
+	team := NewTeam("t", "1.0.0").WithAgents("a")
+	target := Target{Name: "local", Platform: PlatformClaudeCode, Output: filepath.Join(t.TempDir(), ".claude", "agents")}
+	d := NewDeployment("t").AddTarget(target)
+	if err := d.FillDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	files, err := g.Generate(d.Targets[0], team, []*Agent{NewAgent("a", "")})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	for name, data := range files {
+		full := filepath.Join(target.Output, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"a.md", ClaudeCodeContextFile} {
+		if _, err := os.Stat(filepath.Join(target.Output, name)); err != nil {
+			t.Errorf("%s was not written under Output: %v (files: %v)", name, err, files)
+		}
+	}
+}
+
+func TestRegisterGenerator(t *testing.T) {
+	const p Platform = "acme-internal"
+	defer func() {
+		generatorsMu.Lock()
+		delete(generators, p)
+		generatorsMu.Unlock()
+	}()
+
+	RegisterGenerator(p, GeneratorFunc(func(target Target, team *Team, agents []*Agent) (map[string][]byte, error) {
+		return map[string][]byte{target.Name + ".txt": []byte(team.Name)}, nil
+	}))
+
+	g, ok := GetGenerator(p)
+	if !ok {
+		t.Fatal("GetGenerator did not return the registered generator")
+	}
+	files, err := g.Generate(Target{Name: "prod"}, NewTeam("stats", "1.0.0"), nil)
+	if err != nil || string(files["prod.txt"]) != "stats" {
+		t.Errorf("Generate() = %v, %v", files, err)
+	}
+}
+
+func TestRegisterGeneratorNil(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("RegisterGenerator(nil) should panic")
+		}
+	}()
+	RegisterGenerator("acme-internal", nil)
+}
+
+func TestKubernetesGeneratorUsesPlatformModel(t *testing.T) {
+	agent := NewAgent("a", "")
+	agent.ModelByPlatform = map[Platform]Model{PlatformAWSEKS: ModelOpus}
+	g, _ := GetGenerator(PlatformAWSEKS)
+	files, err := g.Generate(Target{Name: "eks", Platform: PlatformAWSEKS}, NewTeam("t", "1.0.0").WithAgents("a"), []*Agent{agent})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !strings.Contains(string(files["a-deployment.yaml"]), "value: opus") {
+		t.Errorf("deployment does not use the platform model:\n%s", files["a-deployment.yaml"])
+	}
+	if agent.Model != ModelSonnet {
+		t.Errorf("Generate modified the agent's model: %q", agent.Model)
+	}
+}
+
+func TestGeneratorsResolvePlatformModels(t *testing.T) {
+	agent := NewAgent("a", "")
+	agent.ModelByPlatform = map[Platform]Model{PlatformKubernetes: ModelHaiku, PlatformClaudeCode: ModelOpus}
+	team := NewTeam("t", "1.0.0").WithAgents("a")
+
+	files, err := GenerateKubernetesManifests(team, []*Agent{agent, nil}, KubernetesConfig{Namespace: "agents"})
+	if err != nil {
+		t.Fatalf("GenerateKubernetesManifests failed: %v", err)
+	}
+	if !strings.Contains(string(files["a-deployment.yaml"]), "value: haiku") {
+		t.Errorf("manifest does not use the kubernetes model:\n%s", files["a-deployment.yaml"])
+	}
+
+	g, _ := GetGenerator(PlatformClaudeCode)
+	files, err = g.Generate(Target{Name: "local", Platform: PlatformClaudeCode}, team, []*Agent{nil, agent})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !strings.Contains(string(files["a.md"]), "model: opus") {
+		t.Errorf("subagent file does not use the claude-code model:\n%s", files["a.md"])
+	}
+
+	g, _ = GetGenerator(PlatformAWSEKS)
+	if _, err := g.Generate(Target{Name: "eks", Platform: PlatformAWSEKS}, team, []*Agent{nil, agent}); err != nil {
+		t.Errorf("Generate with a nil agent failed: %v", err)
+	}
+}
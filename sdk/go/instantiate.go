@@ -0,0 +1,86 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// varPlaceholder matches ${VAR} placeholders.
+	varPlaceholder = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+	// envPlaceholder matches {{.env}} placeholders, with optional spaces.
+	envPlaceholder = regexp.MustCompile(`\{\{\s*\.env\s*\}\}`)
+)
+
+// Instantiate returns a concrete copy of a template deployment for env.
+// Placeholders in every string of every target, including names, outputs,
+// and platform configs, are expanded: {{.env}} becomes env and ${VAR}
+// becomes vars["VAR"]. It returns an error naming any ${VAR} missing from
+// vars. The result is not validated; call Validate on it as needed.
+func (d *Deployment) Instantiate(env string, vars map[string]string) (*Deployment, error) {
+	if env == "" {
+		return nil, fmt.Errorf("instantiate deployment: env is required")
+	}
+
+	missing := make(map[string]bool)
+	expand := func(s string) string {
+		s = envPlaceholder.ReplaceAllLiteralString(s, env)
+		return varPlaceholder.ReplaceAllStringFunc(s, func(m string) string {
+			name := m[2 : len(m)-1]
+			v, ok := vars[name]
+			if !ok {
+				missing[name] = true
+				return m
+			}
+			return v
+		})
+	}
+
+	out := d.Clone()
+	for i, t := range out.Targets {
+		value, err := toJSONValue(t)
+		if err != nil {
+			return nil, fmt.Errorf("instantiate deployment: targets[%d]: %w", i, err)
+		}
+		data, err := json.Marshal(expandStrings(value, expand))
+		if err != nil {
+			return nil, fmt.Errorf("instantiate deployment: targets[%d]: %w", i, err)
+		}
+		var expanded Target
+		if err := json.Unmarshal(data, &expanded); err != nil {
+			return nil, fmt.Errorf("instantiate deployment: targets[%d]: %w", i, err)
+		}
+		out.Targets[i] = expanded
+	}
+
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("instantiate deployment: undefined variables: %s", strings.Join(names, ", "))
+	}
+	return out, nil
+}
+
+// expandStrings applies expand to every string in a decoded JSON value.
+func expandStrings(v interface{}, expand func(string) string) interface{} {
+	switch v := v.(type) {
+	case string:
+		return expand(v)
+	case []interface{}:
+		for i := range v {
+			v[i] = expandStrings(v[i], expand)
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = expandStrings(v[k], expand)
+		}
+	}
+	return v
+}
@@ -0,0 +1,54 @@
+package multiagentspec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDeploymentInstantiate(t *testing.T) {
+	tmpl := NewDeployment("stats").
+		AddTarget(Target{
+			Name:     "eks-{{.env}}",
+			Platform: PlatformAWSEKS,
+			Output:   "deploy/{{ .env }}",
+			Kubernetes: &KubernetesConfig{
+				Namespace:     "agents-{{.env}}",
+				ImageRegistry: "${REGISTRY}/agents",
+				Sidecars:      []Container{{Name: "proxy", Image: "envoy:${ENVOY_TAG}", Env: map[string]string{"STAGE": "{{.env}}"}}},
+			},
+		})
+
+	d, err := tmpl.Instantiate("prod", map[string]string{"REGISTRY": "ghcr.io/acme", "ENVOY_TAG": "1.30"})
+	if err != nil {
+		t.Fatalf("Instantiate failed: %v", err)
+	}
+
+	target := d.Targets[0]
+	if target.Name != "eks-prod" || target.Output != "deploy/prod" {
+		t.Errorf("target = %s, %s", target.Name, target.Output)
+	}
+	k := target.Kubernetes
+	if k.Namespace != "agents-prod" || k.ImageRegistry != "ghcr.io/acme/agents" {
+		t.Errorf("kubernetes = %+v", k)
+	}
+	if k.Sidecars[0].Image != "envoy:1.30" || k.Sidecars[0].Env["STAGE"] != "prod" {
+		t.Errorf("sidecar = %+v", k.Sidecars[0])
+	}
+
+	if tmpl.Targets[0].Name != "eks-{{.env}}" || tmpl.Targets[0].Kubernetes.Sidecars[0].Env["STAGE"] != "{{.env}}" {
+		t.Error("Instantiate modified the template")
+	}
+}
+
+func TestDeploymentInstantiateErrors(t *testing.T) {
+	tmpl := NewDeployment("stats").
+		AddTarget(Target{Name: "${B}-${A}", Platform: PlatformKubernetes, Output: "${A}"})
+
+	_, err := tmpl.Instantiate("dev", nil)
+	if err == nil || !strings.Contains(err.Error(), "undefined variables: A, B") {
+		t.Errorf("Instantiate error = %v", err)
+	}
+	if _, err := tmpl.Instantiate("", map[string]string{"A": "a", "B": "b"}); err == nil {
+		t.Error("Instantiate should require env")
+	}
+}
@@ -1,9 +1,44 @@
 package multiagentspec
 
 import (
+	"encoding/json"
+
 	"github.com/invopop/jsonschema"
 )
 
+// DeploymentSchemaID is the canonical URL of the published Deployment schema.
+const DeploymentSchemaID = "https://raw.githubusercontent.com/agentplexus/multi-agent-spec/main/schema/deployment/deployment.schema.json"
+
+// DeploymentSchema returns the JSON Schema for the Deployment type, indented
+// exactly as published in schema/deployment/deployment.schema.json.
+func DeploymentSchema() json.RawMessage {
+	return reflectSchema(&Deployment{},
+		"Multi-Agent Spec - Deployment Definition",
+		"Schema for defining deployment targets for multi-agent systems",
+		DeploymentSchemaID)
+}
+
+// reflectSchema generates the indented JSON Schema for v.
+func reflectSchema(v interface{}, title, description, id string) json.RawMessage {
+	r := &jsonschema.Reflector{
+		DoNotReference:            false, // Use $ref for named types
+		ExpandedStruct:            false,
+		AllowAdditionalProperties: false,
+	}
+
+	schema := r.Reflect(v)
+	schema.Title = title
+	schema.Description = description
+	schema.ID = jsonschema.ID(id)
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		// The schema consists solely of JSON-encodable values.
+		panic("multiagentspec: marshal schema: " + err.Error())
+	}
+	return data
+}
+
 // JSONSchema implements jsonschema.Schema for Model type.
 func (Model) JSONSchema() *jsonschema.Schema {
 	return &jsonschema.Schema{
@@ -96,3 +131,12 @@ func (Status) JSONSchema() *jsonschema.Schema {
 		Description: "Validation status",
 	}
 }
+
+// JSONSchema implements jsonschema.Schema for MemoryType type.
+func (MemoryType) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "string",
+		Enum:        []interface{}{"ephemeral", "persistent"},
+		Description: "Agent memory lifetime",
+	}
+}
@@ -0,0 +1,287 @@
+package multiagentspec
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// k8sManifest is a Kubernetes apps/v1 Deployment.
+type k8sManifest struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   k8sMetadata       `yaml:"metadata"`
+	Spec       k8sDeploymentSpec `yaml:"spec"`
+}
+
+type k8sMetadata struct {
+	Name      string            `yaml:"name,omitempty"`
+	Namespace string            `yaml:"namespace,omitempty"`
+	Labels    map[string]string `yaml:"labels,omitempty"`
+}
+
+type k8sDeploymentSpec struct {
+	Replicas int            `yaml:"replicas"`
+	Selector k8sSelector    `yaml:"selector"`
+	Template k8sPodTemplate `yaml:"template"`
+}
+
+type k8sSelector struct {
+	MatchLabels map[string]string `yaml:"matchLabels"`
+}
+
+type k8sPodTemplate struct {
+	Metadata k8sMetadata `yaml:"metadata"`
+	Spec     k8sPodSpec  `yaml:"spec"`
+}
+
+type k8sPodSpec struct {
+	Containers []k8sContainer `yaml:"containers"`
+}
+
+type k8sContainer struct {
+	Name           string        `yaml:"name"`
+	Image          string        `yaml:"image"`
+	Env            []k8sEnvVar   `yaml:"env,omitempty"`
+	Resources      *k8sResources `yaml:"resources,omitempty"`
+	LivenessProbe  *k8sProbe     `yaml:"livenessProbe,omitempty"`
+	ReadinessProbe *k8sProbe     `yaml:"readinessProbe,omitempty"`
+}
+
+type k8sEnvVar struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+type k8sProbe struct {
+	HTTPGet             k8sHTTPGetAction `yaml:"httpGet"`
+	InitialDelaySeconds int              `yaml:"initialDelaySeconds"`
+	PeriodSeconds       int              `yaml:"periodSeconds"`
+	TimeoutSeconds      int              `yaml:"timeoutSeconds"`
+	FailureThreshold    int              `yaml:"failureThreshold"`
+}
+
+type k8sHTTPGetAction struct {
+	Path string `yaml:"path"`
+	Port int    `yaml:"port"`
+}
+
+type k8sResources struct {
+	Limits map[string]string `yaml:"limits,omitempty"`
+}
+
+// GenerateKubernetesManifests generates a Deployment manifest for each agent
+// in the team. The result maps relative file paths ("<agent>-deployment.yaml")
+// to YAML content. Pods carry the team and agent MetricLabels in addition to
+// the app.kubernetes.io labels. Sidecars from cfg are added to every agent
+// pod. The agent container gets HTTP liveness and readiness probes from
+// cfg.Probes, or from DefaultProbeConfig when Probes is nil. AGENT_MODEL is
+// the agent's model for PlatformKubernetes (see Agent.ModelFor).
+func GenerateKubernetesManifests(team *Team, agents []*Agent, cfg KubernetesConfig) (map[string][]byte, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("kubernetes config: %w", err)
+	}
+
+	members, err := teamMembers(team, agents)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte, len(members))
+	for _, agent := range members {
+		data, err := kubernetesManifest(team, agent, cfg)
+		if err != nil {
+			return nil, err
+		}
+		files[agent.Name+"-deployment.yaml"] = data
+	}
+	return files, nil
+}
+
+// StreamKubernetesManifests writes the same manifests as
+// GenerateKubernetesManifests to w as a multi-document YAML stream separated
+// by "---", one agent at a time. The output can be piped directly to
+// `kubectl apply -f -`.
+func StreamKubernetesManifests(w io.Writer, team *Team, agents []*Agent, cfg KubernetesConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("kubernetes config: %w", err)
+	}
+
+	members, err := teamMembers(team, agents)
+	if err != nil {
+		return err
+	}
+
+	for i, agent := range members {
+		data, err := kubernetesManifest(team, agent, cfg)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return fmt.Errorf("write manifest: %w", err)
+			}
+		}
+		if _, err := w.Write(data); err != nil {
+			return fmt.Errorf("write manifest: %w", err)
+		}
+	}
+	return nil
+}
+
+// kubernetesManifest renders the Deployment manifest for a single agent.
+func kubernetesManifest(team *Team, agent *Agent, cfg KubernetesConfig) ([]byte, error) {
+	for _, sc := range cfg.Sidecars {
+		if sc.Name == agent.Name {
+			return nil, fmt.Errorf("agent %s: sidecar name %q conflicts with agent container", agent.Name, sc.Name)
+		}
+	}
+
+	labels := map[string]string{
+		"app.kubernetes.io/name":      agent.Name,
+		"app.kubernetes.io/part-of":   team.Name,
+		"app.kubernetes.io/component": "agent",
+	}
+	selector := map[string]string{
+		"app.kubernetes.io/name":    agent.Name,
+		"app.kubernetes.io/part-of": team.Name,
+	}
+
+	podLabels := make(map[string]string)
+	for _, m := range []map[string]string{team.MetricLabels(), agent.MetricLabels(), labels} {
+		for k, v := range m {
+			podLabels[k] = v
+		}
+	}
+
+	probes := cfg.Probes.WithDefaults()
+	containers := []k8sContainer{{
+		Name:  agent.Name,
+		Image: cfg.ImageFor(agent.Name),
+		Env: []k8sEnvVar{
+			{Name: "AGENT_NAME", Value: agent.Name},
+			{Name: "AGENT_MODEL", Value: string(agent.ModelFor(PlatformKubernetes))},
+		},
+		Resources:      k8sResourcesFor(cfg.ResourceLimits),
+		LivenessProbe:  k8sProbeFor(probes, probes.LivenessPath),
+		ReadinessProbe: k8sProbeFor(probes, probes.ReadinessPath),
+	}}
+	for _, sc := range cfg.Sidecars {
+		containers = append(containers, k8sContainer{
+			Name:      sc.Name,
+			Image:     sc.Image,
+			Env:       k8sEnv(sc.Env),
+			Resources: k8sResourcesFor(sc.ResourceLimits),
+		})
+	}
+
+	manifest := k8sManifest{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Metadata: k8sMetadata{
+			Name:      agent.Name,
+			Namespace: cfg.Namespace,
+			Labels:    labels,
+		},
+		Spec: k8sDeploymentSpec{
+			Replicas: 1,
+			Selector: k8sSelector{MatchLabels: selector},
+			Template: k8sPodTemplate{
+				Metadata: k8sMetadata{Labels: podLabels},
+				Spec:     k8sPodSpec{Containers: containers},
+			},
+		},
+	}
+
+	return marshalYAML(manifest)
+}
+
+// k8sEnv converts an environment map to a list of env vars sorted by name.
+func k8sEnv(env map[string]string) []k8sEnvVar {
+	if len(env) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	vars := make([]k8sEnvVar, 0, len(names))
+	for _, name := range names {
+		vars = append(vars, k8sEnvVar{Name: name, Value: env[name]})
+	}
+	return vars
+}
+
+// k8sResourcesFor converts ResourceLimits to container resource limits.
+func k8sResourcesFor(limits *ResourceLimits) *k8sResources {
+	if limits == nil {
+		return nil
+	}
+	m := make(map[string]string)
+	if limits.CPU != "" {
+		m["cpu"] = limits.CPU
+	}
+	if limits.Memory != "" {
+		m["memory"] = limits.Memory
+	}
+	if limits.GPU > 0 {
+		m["nvidia.com/gpu"] = fmt.Sprint(limits.GPU)
+	}
+	if len(m) == 0 {
+		return nil
+	}
+	return &k8sResources{Limits: m}
+}
+
+// k8sProbeFor converts a probe config to an HTTP GET probe on path.
+func k8sProbeFor(p ProbeConfig, path string) *k8sProbe {
+	return &k8sProbe{
+		HTTPGet:             k8sHTTPGetAction{Path: path, Port: p.Port},
+		InitialDelaySeconds: p.InitialDelaySeconds,
+		PeriodSeconds:       p.PeriodSeconds,
+		TimeoutSeconds:      p.TimeoutSeconds,
+		FailureThreshold:    p.FailureThreshold,
+	}
+}
+
+// teamMembers returns the agents listed in team.Agents, in team order.
+// Agents are matched by qualified name or plain name; nil agents are skipped.
+func teamMembers(team *Team, agents []*Agent) ([]*Agent, error) {
+	byName := make(map[string]*Agent, len(agents))
+	for _, a := range agents {
+		if a == nil {
+			continue
+		}
+		byName[a.Name] = a
+		byName[a.QualifiedName()] = a
+	}
+
+	members := make([]*Agent, 0, len(team.Agents))
+	for _, name := range team.Agents {
+		agent, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("team %s: agent %q not found", team.Name, name)
+		}
+		members = append(members, agent)
+	}
+	return members, nil
+}
+
+// marshalYAML encodes v as YAML with two-space indentation.
+func marshalYAML(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, fmt.Errorf("encode yaml: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("encode yaml: %w", err)
+	}
+	return buf.Bytes(), nil
+}
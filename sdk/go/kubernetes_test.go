@@ -0,0 +1,253 @@
+package multiagentspec
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenerateKubernetesManifests(t *testing.T) {
+	team := NewTeam("stats-team", "1.0.0").WithAgents("research", "synthesis")
+	agents := []*Agent{
+		NewAgent("research", "").WithModel(ModelHaiku),
+		NewAgent("synthesis", ""),
+	}
+	cfg := KubernetesConfig{
+		Namespace:      "agents",
+		ImageRegistry:  "ghcr.io/acme",
+		ResourceLimits: &ResourceLimits{CPU: "500m", Memory: "512Mi"},
+		Sidecars: []Container{{
+			Name:  "otel-collector",
+			Image: "otel/opentelemetry-collector:0.100.0",
+			Env:   map[string]string{"OTEL_EXPORTER": "otlp"},
+		}},
+	}
+
+	files, err := GenerateKubernetesManifests(team, agents, cfg)
+	if err != nil {
+		t.Fatalf("GenerateKubernetesManifests failed: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("len(files) = %d, want 2", len(files))
+	}
+
+	data, ok := files["research-deployment.yaml"]
+	if !ok {
+		t.Fatalf("research-deployment.yaml not generated; got %v", files)
+	}
+
+	var manifest k8sManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("yaml.Unmarshal failed: %v\n%s", err, data)
+	}
+
+	if manifest.Kind != "Deployment" || manifest.Metadata.Namespace != "agents" {
+		t.Errorf("unexpected metadata: %+v", manifest)
+	}
+	containers := manifest.Spec.Template.Spec.Containers
+	if len(containers) != 2 {
+		t.Fatalf("len(containers) = %d, want 2", len(containers))
+	}
+	if containers[0].Image != "ghcr.io/acme/research:latest" {
+		t.Errorf("Image = %q", containers[0].Image)
+	}
+	if containers[0].Resources == nil || containers[0].Resources.Limits["memory"] != "512Mi" {
+		t.Errorf("Resources = %+v", containers[0].Resources)
+	}
+	if containers[1].Name != "otel-collector" || containers[1].Env[0].Value != "otlp" {
+		t.Errorf("sidecar = %+v", containers[1])
+	}
+	podLabels := manifest.Spec.Template.Metadata.Labels
+	if podLabels["model"] != "haiku" || podLabels["team"] != "stats-team" || podLabels["app.kubernetes.io/name"] != "research" {
+		t.Errorf("pod labels = %v", podLabels)
+	}
+	if _, ok := manifest.Metadata.Labels["model"]; ok {
+		t.Errorf("metric labels should only be set on pods: %v", manifest.Metadata.Labels)
+	}
+	if !strings.Contains(string(data), "value: haiku") {
+		t.Errorf("manifest should carry agent model:\n%s", data)
+	}
+}
+
+func TestGenerateKubernetesManifestsErrors(t *testing.T) {
+	team := NewTeam("t", "1.0.0").WithAgents("a")
+	agents := []*Agent{NewAgent("a", "")}
+
+	dup := KubernetesConfig{Sidecars: []Container{
+		{Name: "proxy", Image: "envoy"},
+		{Name: "proxy", Image: "envoy"},
+	}}
+	if _, err := GenerateKubernetesManifests(team, agents, dup); err == nil {
+		t.Error("expected error for duplicate sidecar names")
+	}
+
+	clash := KubernetesConfig{Sidecars: []Container{{Name: "a", Image: "envoy"}}}
+	if _, err := GenerateKubernetesManifests(team, agents, clash); err == nil {
+		t.Error("expected error for sidecar named after agent container")
+	}
+
+	missing := NewTeam("t", "1.0.0").WithAgents("a", "b")
+	if _, err := GenerateKubernetesManifests(missing, agents, KubernetesConfig{}); err == nil {
+		t.Error("expected error for agent missing from definitions")
+	}
+}
+
+func TestKubernetesConfigValidate(t *testing.T) {
+	cfg := KubernetesConfig{Sidecars: []Container{{Name: "", Image: ""}}}
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate() = nil, want error")
+	}
+	if !strings.Contains(err.Error(), "sidecars[0].name: is required") ||
+		!strings.Contains(err.Error(), "sidecars[0].image: is required") {
+		t.Errorf("Validate() = %q", err)
+	}
+}
+
+func TestStreamKubernetesManifests(t *testing.T) {
+	team := NewTeam("t", "1.0.0").WithAgents("a", "b", "c")
+	agents := []*Agent{NewAgent("a", ""), NewAgent("b", ""), NewAgent("c", "")}
+	cfg := KubernetesConfig{Namespace: "agents"}
+
+	var buf bytes.Buffer
+	if err := StreamKubernetesManifests(&buf, team, agents, cfg); err != nil {
+		t.Fatalf("StreamKubernetesManifests failed: %v", err)
+	}
+
+	files, err := GenerateKubernetesManifests(team, agents, cfg)
+	if err != nil {
+		t.Fatalf("GenerateKubernetesManifests failed: %v", err)
+	}
+
+	docs := strings.Split(buf.String(), "---\n")
+	if len(docs) != 3 {
+		t.Fatalf("len(docs) = %d, want 3:\n%s", len(docs), buf.String())
+	}
+	for i, name := range team.Agents {
+		if docs[i] != string(files[name+"-deployment.yaml"]) {
+			t.Errorf("doc %d does not match generated manifest for %s", i, name)
+		}
+	}
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("broken pipe") }
+
+func TestStreamKubernetesManifestsWriteError(t *testing.T) {
+	team := NewTeam("t", "1.0.0").WithAgents("a")
+	err := StreamKubernetesManifests(failingWriter{}, team, []*Agent{NewAgent("a", "")}, KubernetesConfig{})
+	if err == nil || !strings.Contains(err.Error(), "broken pipe") {
+		t.Errorf("StreamKubernetesManifests error = %v, want write error", err)
+	}
+}
+
+func TestKubernetesConfigValidateImageRegistry(t *testing.T) {
+	valid := []string{"", "ghcr.io/acme", "localhost:5000", "registry.example.com/team/agents", "docker.io/my-org"}
+	for _, r := range valid {
+		cfg := KubernetesConfig{ImageRegistry: r}
+		if err := cfg.ValidateImageRegistry(); err != nil {
+			t.Errorf("ValidateImageRegistry(%q) = %v, want nil", r, err)
+		}
+	}
+
+	invalid := map[string]string{
+		"https://ghcr.io/acme": "must not include a URL scheme",
+		"ghcr.io/acme/":        "must not end with a slash",
+		"ghcr.io/Acme":         "not a valid host[/path]",
+		"ghcr.io//acme":        "not a valid host[/path]",
+	}
+	for r, want := range invalid {
+		cfg := KubernetesConfig{ImageRegistry: r}
+		err := cfg.ValidateImageRegistry()
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ValidateImageRegistry(%q) = %v, want %q", r, err, want)
+		}
+	}
+
+	cfg := KubernetesConfig{ImageRegistry: "https://ghcr.io"}
+	if err := cfg.Validate(); err == nil || !strings.HasPrefix(err.Error(), "imageRegistry: ") {
+		t.Errorf("Validate() = %v, want imageRegistry error", err)
+	}
+}
+
+func TestKubernetesConfigImageFor(t *testing.T) {
+	tests := []struct {
+		registry, want string
+	}{
+		{"", "research:latest"},
+		{"ghcr.io/acme", "ghcr.io/acme/research:latest"},
+		{"ghcr.io/acme/", "ghcr.io/acme/research:latest"},
+	}
+	for _, tt := range tests {
+		cfg := KubernetesConfig{ImageRegistry: tt.registry}
+		if got := cfg.ImageFor("research"); got != tt.want {
+			t.Errorf("ImageFor() with registry %q = %q, want %q", tt.registry, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateKubernetesManifestsProbes(t *testing.T) {
+	team := NewTeam("t", "1.0.0").WithAgents("a")
+	agents := []*Agent{NewAgent("a", "")}
+	cfg := KubernetesConfig{Sidecars: []Container{{Name: "proxy", Image: "envoy"}}}
+
+	files, err := GenerateKubernetesManifests(team, agents, cfg)
+	if err != nil {
+		t.Fatalf("GenerateKubernetesManifests failed: %v", err)
+	}
+	var manifest k8sManifest
+	if err := yaml.Unmarshal(files["a-deployment.yaml"], &manifest); err != nil {
+		t.Fatalf("yaml.Unmarshal failed: %v", err)
+	}
+	containers := manifest.Spec.Template.Spec.Containers
+	live, ready := containers[0].LivenessProbe, containers[0].ReadinessProbe
+	if live == nil || live.HTTPGet.Path != "/healthz" || live.HTTPGet.Port != 8080 || live.FailureThreshold != 3 {
+		t.Errorf("default liveness probe = %+v", live)
+	}
+	if ready == nil || ready.HTTPGet.Path != "/readyz" {
+		t.Errorf("default readiness probe = %+v", ready)
+	}
+	if containers[1].LivenessProbe != nil || containers[1].ReadinessProbe != nil {
+		t.Errorf("sidecar should not get probes: %+v", containers[1])
+	}
+
+	cfg.Probes = &ProbeConfig{ReadinessPath: "/ready", Port: 9000, PeriodSeconds: 30}
+	files, err = GenerateKubernetesManifests(team, agents, cfg)
+	if err != nil {
+		t.Fatalf("GenerateKubernetesManifests failed: %v", err)
+	}
+	manifest = k8sManifest{}
+	if err := yaml.Unmarshal(files["a-deployment.yaml"], &manifest); err != nil {
+		t.Fatalf("yaml.Unmarshal failed: %v", err)
+	}
+	ready = manifest.Spec.Template.Spec.Containers[0].ReadinessProbe
+	if ready.HTTPGet.Path != "/ready" || ready.HTTPGet.Port != 9000 || ready.PeriodSeconds != 30 || ready.TimeoutSeconds != 1 {
+		t.Errorf("custom readiness probe = %+v", ready)
+	}
+}
+
+func TestProbeConfigValidate(t *testing.T) {
+	cfg := KubernetesConfig{Probes: &ProbeConfig{LivenessPath: "healthz", Port: 70000, TimeoutSeconds: -1}}
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate() = nil, want error")
+	}
+	for _, want := range []string{
+		"probes.livenessPath: must start with /",
+		"probes.port: must be between 1 and 65535",
+		"probes.timeoutSeconds: must be non-negative",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %q, want it to contain %q", err, want)
+		}
+	}
+
+	if err := (&ProbeConfig{LivenessPath: "/live", Port: 80}).Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+}
@@ -0,0 +1,421 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LintWarning is a non-fatal finding about a definition that is valid but
+// likely to be a mistake.
+type LintWarning struct {
+	// Path locates the finding (e.g., "workflow.steps[0]").
+	Path string `json:"path"`
+
+	// Message describes the finding.
+	Message string `json:"message"`
+}
+
+// String returns the warning formatted as "path: message".
+func (w LintWarning) String() string {
+	if w.Path == "" {
+		return w.Message
+	}
+	return w.Path + ": " + w.Message
+}
+
+// maxInstructionFraction is the share of a model's context window that an
+// agent's instructions may occupy before ValidateInstructionSize warns.
+const maxInstructionFraction = 0.25
+
+// ValidateInstructionSize warns when the agent's Instructions are estimated
+// to occupy more than a quarter of its model's context window, crowding out
+// working context. Agents with models missing from ModelCapabilities are skipped.
+func (a *Agent) ValidateInstructionSize() []LintWarning {
+	model := a.effectiveModel()
+	capability, ok := model.Capabilities()
+	if !ok || capability.ContextWindow <= 0 {
+		return nil
+	}
+
+	tokens := estimateTokens(a.Instructions)
+	limit := int(float64(capability.ContextWindow) * maxInstructionFraction)
+	if tokens <= limit {
+		return nil
+	}
+	return []LintWarning{{
+		Path: "instructions",
+		Message: fmt.Sprintf("instructions are ~%d tokens, over %d (%.0f%% of the %d-token %s context window)",
+			tokens, limit, maxInstructionFraction*100, capability.ContextWindow, model),
+	}}
+}
+
+// modelTaskRule is a heuristic that an agent matching applies needs at least
+// the min model tier; reason describes the matching agent's workload.
+type modelTaskRule struct {
+	min     Model
+	applies func(a *Agent) (reason string, ok bool)
+}
+
+// heavyCommandTasks is the number of command tasks at which an agent is
+// considered to need at least sonnet.
+const heavyCommandTasks = 5
+
+// modelTaskRules drive ValidateModelForTasks.
+var modelTaskRules = []modelTaskRule{
+	{
+		min: ModelSonnet,
+		applies: func(a *Agent) (string, bool) {
+			return "orchestrates sub-agents with the Task tool", canonicalToolSet(a)[ToolTask]
+		},
+	},
+	{
+		min: ModelSonnet,
+		applies: func(a *Agent) (string, bool) {
+			n := 0
+			for _, task := range a.Tasks {
+				if task.Type == TaskTypeCommand {
+					n++
+				}
+			}
+			return fmt.Sprintf("runs %d command tasks", n), n >= heavyCommandTasks
+		},
+	},
+}
+
+// ValidateModelForTasks warns when the agent's model tier is below what its
+// workload needs according to modelTaskRules: agents that use the Task tool,
+// or that run many command tasks, should use at least sonnet. Agents with
+// non-canonical models are skipped.
+func (a *Agent) ValidateModelForTasks() []LintWarning {
+	model := a.effectiveModel()
+	rank := modelRank(model)
+	if rank < 0 {
+		return nil
+	}
+	var warnings []LintWarning
+	for _, rule := range modelTaskRules {
+		reason, ok := rule.applies(a)
+		if !ok || rank >= modelRank(rule.min) {
+			continue
+		}
+		warnings = append(warnings, LintWarning{
+			Path:    "model",
+			Message: fmt.Sprintf("agent %s but uses %s; use at least %s", reason, model, rule.min),
+		})
+	}
+	return warnings
+}
+
+// modelRank returns the position of m in models, from least to most capable,
+// or -1 if m is not a canonical tier.
+func modelRank(m Model) int {
+	for i, known := range models {
+		if m == known {
+			return i
+		}
+	}
+	return -1
+}
+
+// AgentSize pairs an agent with the estimated token count of its Instructions.
+type AgentSize struct {
+	Agent  *Agent `json:"agent"`
+	Tokens int    `json:"tokens"`
+}
+
+// RankByInstructionSize returns the agents sorted by the estimated token size
+// of their Instructions, largest first, using the same estimate as
+// ValidateInstructionSize. Agents of equal size keep their input order; nil
+// agents are skipped.
+func RankByInstructionSize(agents []*Agent) []AgentSize {
+	sizes := make([]AgentSize, 0, len(agents))
+	for _, a := range agents {
+		if a == nil {
+			continue
+		}
+		sizes = append(sizes, AgentSize{Agent: a, Tokens: estimateTokens(a.Instructions)})
+	}
+	sort.SliceStable(sizes, func(i, j int) bool {
+		return sizes[i].Tokens > sizes[j].Tokens
+	})
+	return sizes
+}
+
+// ValidateWorkflowAgainstDependencies cross-checks workflow edges against the
+// Dependencies declared by each step's agent. It warns when a step depends on
+// a step whose agent is not among its own agent's Dependencies, and when an
+// agent declares a dependency on an agent that appears in the workflow but
+// never runs upstream of it. Steps whose agents are not registered are skipped.
+func (t *Team) ValidateWorkflowAgainstDependencies(registry *AgentRegistry) []LintWarning {
+	if t.Workflow == nil {
+		return nil
+	}
+
+	steps := t.Workflow.stepsByName()
+	inWorkflow := make(map[string]bool)
+	for _, step := range t.Workflow.Steps {
+		inWorkflow[step.Agent] = true
+	}
+
+	var warnings []LintWarning
+	for i, step := range t.Workflow.Steps {
+		agent, ok := registry.Get(step.Agent)
+		if !ok {
+			continue
+		}
+		path := fmt.Sprintf("workflow.steps[%d]", i)
+
+		declared := make(map[string]bool)
+		for _, dep := range agent.Dependencies {
+			declared[dependencyName(dep)] = true
+		}
+
+		// Workflow edge without a matching agent dependency.
+		for _, depName := range step.DependsOn {
+			depStep, ok := steps[depName]
+			if !ok || depStep.Agent == step.Agent || declared[depStep.Agent] {
+				continue
+			}
+			warnings = append(warnings, LintWarning{
+				Path: path,
+				Message: fmt.Sprintf("step depends on %s (agent %s) but agent %s does not list %s in dependencies",
+					depName, depStep.Agent, step.Agent, depStep.Agent),
+			})
+		}
+
+		// Agent dependency without a matching workflow edge.
+		upstreamAgents := make(map[string]bool)
+		for name := range t.Workflow.upstream(step.Name) {
+			if s, ok := steps[name]; ok {
+				upstreamAgents[s.Agent] = true
+			}
+		}
+		for _, dep := range agent.Dependencies {
+			dep = dependencyName(dep)
+			if !inWorkflow[dep] || upstreamAgents[dep] {
+				continue
+			}
+			warnings = append(warnings, LintWarning{
+				Path: path,
+				Message: fmt.Sprintf("agent %s depends on %s but no step using %s runs before this step",
+					step.Agent, dep, dep),
+			})
+		}
+	}
+
+	return warnings
+}
+
+// ValidateOrchestratorCapability warns when the team's orchestrator agent is
+// not granted the Task tool, without which it cannot delegate to sub-agents.
+// Teams without an orchestrator, or whose orchestrator is not registered,
+// produce no warnings.
+func (t *Team) ValidateOrchestratorCapability(registry *AgentRegistry) []LintWarning {
+	if t.Orchestrator == "" {
+		return nil
+	}
+	agent, ok := registry.Get(t.Orchestrator)
+	if !ok || agent.hasTool(ToolTask) {
+		return nil
+	}
+	return []LintWarning{{
+		Path:    "orchestrator",
+		Message: fmt.Sprintf("orchestrator %s lacks the %s tool and cannot delegate to other agents", t.Orchestrator, ToolTask),
+	}}
+}
+
+// ValidateOrchestratedWorkflow checks that an orchestrated workflow matches
+// the team's declared Orchestrator. It warns when the team has no
+// orchestrator, when no step uses the orchestrator agent, and when the root
+// steps (those without depends_on) are run by more than one agent, so that
+// several agents appear to act as coordinators. Other workflow types produce
+// no warnings.
+func (t *Team) ValidateOrchestratedWorkflow() []LintWarning {
+	if t.Workflow == nil || t.Workflow.Type != WorkflowOrchestrated {
+		return nil
+	}
+	if t.Orchestrator == "" {
+		return []LintWarning{{
+			Path:    "orchestrator",
+			Message: "orchestrated workflow but the team declares no orchestrator",
+		}}
+	}
+
+	var warnings []LintWarning
+	used := false
+	seen := make(map[string]bool)
+	var coordinators []string
+	for _, step := range t.Workflow.Steps {
+		if step.Agent == t.Orchestrator {
+			used = true
+		}
+		if len(step.DependsOn) == 0 && step.Agent != "" && !seen[step.Agent] {
+			seen[step.Agent] = true
+			coordinators = append(coordinators, step.Agent)
+		}
+	}
+	if !used {
+		warnings = append(warnings, LintWarning{
+			Path:    "workflow",
+			Message: fmt.Sprintf("no step uses the orchestrator %s", t.Orchestrator),
+		})
+	}
+	if len(coordinators) > 1 {
+		warnings = append(warnings, LintWarning{
+			Path:    "workflow",
+			Message: fmt.Sprintf("root steps are run by multiple agents (%s); an orchestrated workflow should have a single coordinator", strings.Join(coordinators, ", ")),
+		})
+	}
+	return warnings
+}
+
+// ValidateRequiredDefault warns when the port is marked Required but also has
+// a Default, which always fills it, so Required has no effect.
+func (p *Port) ValidateRequiredDefault() []LintWarning {
+	if p.Required == nil || !*p.Required || p.Default == nil {
+		return nil
+	}
+	return []LintWarning{{
+		Path:    "required",
+		Message: fmt.Sprintf("input %s is required but has a default, so required has no effect", p.Name),
+	}}
+}
+
+// Lint returns non-fatal warnings about the workflow's steps: currently,
+// inputs that are both required and defaulted (see
+// Port.ValidateRequiredDefault).
+func (w *Workflow) Lint() []LintWarning {
+	var warnings []LintWarning
+	for i, step := range w.Steps {
+		for j := range step.Inputs {
+			path := fmt.Sprintf("steps[%d].inputs[%d]", i, j)
+			for _, warning := range step.Inputs[j].ValidateRequiredDefault() {
+				warning.Path = joinPath(path, warning.Path)
+				warnings = append(warnings, warning)
+			}
+		}
+	}
+	return warnings
+}
+
+// AgentPlatformCompatibility warns about each of the agent's tools that maps
+// lossily onto the platform (see DegradedToolMappings), or that has no real
+// mapping on the platform and would be passed through unchanged (see
+// ValidateToolCoverage).
+func AgentPlatformCompatibility(a *Agent, p Platform) []LintWarning {
+	return agentToolWarnings(a, p, true)
+}
+
+// ValidateToolCoverage warns about each tool of agents that would fall
+// through to passthrough on platform p, meaning the platform has no real
+// mapping for it: on platforms that rename tools, a tool without an entry in
+// the platform's mapping table; elsewhere, a name that is not a canonical
+// tool. Tool names are resolved with CanonicalTool first, and an agent's
+// CustomTools are expected to pass through and are not reported.
+func ValidateToolCoverage(agents []*Agent, p Platform) []LintWarning {
+	var warnings []LintWarning
+	for _, a := range agents {
+		if a == nil {
+			continue
+		}
+		prefix := fmt.Sprintf("agents[%s]", a.QualifiedName())
+		for _, warning := range agentToolWarnings(a, p, false) {
+			warning.Path = joinPath(prefix, warning.Path)
+			warnings = append(warnings, warning)
+		}
+	}
+	return warnings
+}
+
+// agentToolWarnings checks each of the agent's tools against platform p, as
+// described by ValidateToolCoverage. When degraded is true, tools that map
+// lossily (see DegradedToolMappings) are reported instead.
+func agentToolWarnings(a *Agent, p Platform, degraded bool) []LintWarning {
+	mapping := platformToolMap(p)
+	custom := make(map[string]bool, len(a.CustomTools))
+	for _, ct := range a.CustomTools {
+		custom[ct.Name] = true
+	}
+
+	var warnings []LintWarning
+	seen := make(map[string]bool, len(a.Tools))
+	for _, name := range a.Tools {
+		if custom[name] || seen[name] {
+			continue
+		}
+		seen[name] = true
+		path := fmt.Sprintf("tools[%s]", name)
+
+		tool, canonical := CanonicalTool(name)
+		if canonical {
+			if reason, ok := DegradedToolMappings[p][tool]; ok && degraded {
+				warnings = append(warnings, LintWarning{
+					Path:    path,
+					Message: fmt.Sprintf("%s on %s %s", name, p, reason),
+				})
+				continue
+			}
+			if mapping == nil {
+				continue
+			}
+			if _, ok := mapping[tool]; ok {
+				continue
+			}
+		}
+		warnings = append(warnings, LintWarning{
+			Path:    path,
+			Message: fmt.Sprintf("%s has no mapping on %s and will be passed through unchanged", name, p),
+		})
+	}
+	return warnings
+}
+
+// commonWordTools are canonical tools whose names are also ordinary English
+// words, so a bare mention in instructions is not treated as a tool reference.
+var commonWordTools = map[Tool]bool{
+	ToolRead:  true,
+	ToolWrite: true,
+	ToolEdit:  true,
+	ToolTask:  true,
+}
+
+// ValidateInstructionToolMentions warns about each canonical tool mentioned in
+// Instructions that is not in the agent's Tools. Distinctive names such as Grep
+// or WebFetch count wherever they appear as a word; names that are also common
+// words (Read, Write, Edit, Task) count only as "Read tool" or "`Read`".
+func (a *Agent) ValidateInstructionToolMentions() []LintWarning {
+	var warnings []LintWarning
+	for _, tool := range canonicalTools {
+		if a.hasTool(tool) || !mentionsTool(a.Instructions, tool) {
+			continue
+		}
+		warnings = append(warnings, LintWarning{
+			Path:    "instructions",
+			Message: fmt.Sprintf("instructions mention the %s tool but it is not in tools", tool),
+		})
+	}
+	return warnings
+}
+
+// toolMentions holds the pattern matching a mention of each canonical tool
+// in instructions.
+var toolMentions = func() map[Tool]*regexp.Regexp {
+	patterns := make(map[Tool]*regexp.Regexp, len(canonicalTools))
+	for _, tool := range canonicalTools {
+		name := regexp.QuoteMeta(string(tool))
+		pattern := `\b` + name + `\b`
+		if commonWordTools[tool] {
+			pattern = `(\b` + name + ` tool\b|` + "`" + name + "`" + `)`
+		}
+		patterns[tool] = regexp.MustCompile(pattern)
+	}
+	return patterns
+}()
+
+// mentionsTool reports whether text refers to the canonical tool by name.
+func mentionsTool(text string, tool Tool) bool {
+	re, ok := toolMentions[tool]
+	return ok && re.MatchString(text)
+}
@@ -0,0 +1,309 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestLintWarningString(t *testing.T) {
+	w := LintWarning{Path: "workflow.steps[a]", Message: "something"}
+	if got := w.String(); got != "workflow.steps[a]: something" {
+		t.Errorf("String() = %q", got)
+	}
+	w.Path = ""
+	if got := w.String(); got != "something" {
+		t.Errorf("String() without path = %q", got)
+	}
+}
+
+func TestValidateWorkflowAgainstDependencies(t *testing.T) {
+	research := NewAgent("research", "")
+	synthesis := NewAgent("synthesis", "")
+	verify := NewAgent("verify", "")
+	verify.Dependencies = []string{"synthesis", "research"}
+
+	team := NewTeam("t", "1.0.0").WithAgents("research", "synthesis", "verify")
+	team.Workflow = &Workflow{
+		Type: WorkflowDAG,
+		Steps: []Step{
+			{Name: "verify", Agent: "verify"},
+			{Name: "research", Agent: "research"},
+			{Name: "synthesis", Agent: "synthesis", DependsOn: []string{"research"}},
+		},
+	}
+	registry := newTestRegistry(t, research, synthesis, verify)
+
+	warnings := team.ValidateWorkflowAgainstDependencies(registry)
+	if len(warnings) != 3 {
+		t.Fatalf("len(warnings) = %d, want 3: %v", len(warnings), warnings)
+	}
+	// synthesis step depends on research, but synthesis agent lacks the dependency.
+	if warnings[0].Path != "workflow.steps[0]" || warnings[2].Path != "workflow.steps[2]" {
+		t.Errorf("unexpected warning paths: %v", warnings)
+	}
+	if !strings.Contains(warnings[2].Message, "does not list research") {
+		t.Errorf("warnings[2] = %q", warnings[2].Message)
+	}
+
+	// Fix both directions.
+	synthesis.Dependencies = []string{"research"}
+	team.Workflow.Steps[0].DependsOn = []string{"synthesis"}
+	if warnings := team.ValidateWorkflowAgainstDependencies(registry); len(warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", warnings)
+	}
+}
+
+func TestValidateWorkflowAgainstDependenciesNoWorkflow(t *testing.T) {
+	team := NewTeam("t", "1.0.0")
+	if warnings := team.ValidateWorkflowAgainstDependencies(nil); warnings != nil {
+		t.Errorf("expected nil warnings, got %v", warnings)
+	}
+}
+
+func TestValidateInstructionSize(t *testing.T) {
+	agent := NewAgent("a", "").WithInstructions("Be concise.")
+	if warnings := agent.ValidateInstructionSize(); len(warnings) != 0 {
+		t.Errorf("short instructions produced warnings: %v", warnings)
+	}
+
+	window := ModelCapabilities[ModelHaiku].ContextWindow
+	agent.WithModel(ModelHaiku).WithInstructions(strings.Repeat("x", window*2))
+	warnings := agent.ValidateInstructionSize()
+	if len(warnings) != 1 {
+		t.Fatalf("len(warnings) = %d, want 1", len(warnings))
+	}
+	if warnings[0].Path != "instructions" || !strings.Contains(warnings[0].Message, "haiku context window") {
+		t.Errorf("warning = %v", warnings[0])
+	}
+
+	agent.Model = "custom-model"
+	if warnings := agent.ValidateInstructionSize(); warnings != nil {
+		t.Errorf("unknown model should be skipped, got %v", warnings)
+	}
+}
+
+func TestRankByInstructionSize(t *testing.T) {
+	agents := []*Agent{
+		NewAgent("short", "").WithInstructions("Be brief."),
+		nil,
+		NewAgent("long", "").WithInstructions(strings.Repeat("x", 400)),
+		NewAgent("empty", ""),
+		NewAgent("also-short", "").WithInstructions("Be terse"),
+	}
+
+	ranked := RankByInstructionSize(agents)
+	var got []string
+	for _, s := range ranked {
+		got = append(got, fmt.Sprintf("%s=%d", s.Agent.Name, s.Tokens))
+	}
+	want := "long=100 short=3 also-short=2 empty=0"
+	if strings.Join(got, " ") != want {
+		t.Errorf("RankByInstructionSize() = %v, want %s", got, want)
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"", 0},
+		{"abc", 1},
+		{"abcd", 1},
+		{"abcde", 2},
+	}
+	for _, tt := range tests {
+		if got := estimateTokens(tt.s); got != tt.want {
+			t.Errorf("estimateTokens(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestValidateOrchestratorCapability(t *testing.T) {
+	lead := NewAgent("lead", "").WithTools("Read")
+	registry := newTestRegistry(t, lead)
+	team := NewTeam("t", "1.0.0").WithAgents("lead").WithOrchestrator("lead")
+
+	warnings := team.ValidateOrchestratorCapability(registry)
+	if len(warnings) != 1 || warnings[0].Path != "orchestrator" {
+		t.Fatalf("warnings = %v, want one orchestrator warning", warnings)
+	}
+
+	lead.WithTools("Read", "Task")
+	if warnings := team.ValidateOrchestratorCapability(registry); len(warnings) != 0 {
+		t.Errorf("warnings = %v, want none", warnings)
+	}
+
+	if warnings := NewTeam("t", "1.0.0").ValidateOrchestratorCapability(registry); warnings != nil {
+		t.Errorf("team without orchestrator produced %v", warnings)
+	}
+	if warnings := team.ValidateOrchestratorCapability(nil); warnings != nil {
+		t.Errorf("unregistered orchestrator produced %v", warnings)
+	}
+}
+
+func TestValidateOrchestratedWorkflow(t *testing.T) {
+	team := NewTeam("t", "1.0.0").WithAgents("lead", "research", "writer").WithWorkflow(&Workflow{
+		Type: WorkflowOrchestrated,
+		Steps: []Step{
+			{Name: "plan", Agent: "lead"},
+			{Name: "gather", Agent: "research", DependsOn: []string{"plan"}},
+		},
+	})
+
+	if warnings := team.ValidateOrchestratedWorkflow(); len(warnings) != 1 || warnings[0].Path != "orchestrator" {
+		t.Errorf("warnings = %v, want missing orchestrator warning", warnings)
+	}
+
+	team.WithOrchestrator("lead")
+	if warnings := team.ValidateOrchestratedWorkflow(); len(warnings) != 0 {
+		t.Errorf("warnings = %v, want none", warnings)
+	}
+
+	team.WithOrchestrator("writer")
+	team.Workflow.Steps = append(team.Workflow.Steps, Step{Name: "side", Agent: "research"})
+	var got []string
+	for _, w := range team.ValidateOrchestratedWorkflow() {
+		got = append(got, w.String())
+	}
+	want := []string{
+		"workflow: no step uses the orchestrator writer",
+		"workflow: root steps are run by multiple agents (lead, research); an orchestrated workflow should have a single coordinator",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("warnings = %v, want %v", got, want)
+	}
+
+	team.Workflow.Type = WorkflowDAG
+	if warnings := team.ValidateOrchestratedWorkflow(); warnings != nil {
+		t.Errorf("dag workflow produced %v", warnings)
+	}
+}
+
+func TestAgentPlatformCompatibility(t *testing.T) {
+	agent := NewAgent("a", "").WithTools("Read", "WebSearch", "Edit", "CustomLookup", "lookup")
+	agent.CustomTools = []CustomTool{{Name: "lookup"}}
+
+	claude := AgentPlatformCompatibility(agent, PlatformClaudeCode)
+	if len(claude) != 1 || claude[0].Path != "tools[CustomLookup]" {
+		t.Errorf("claude-code warnings = %v, want one passthrough warning", claude)
+	}
+
+	kiro := AgentPlatformCompatibility(agent, PlatformKiroCLI)
+	if len(kiro) != 1 || kiro[0].Path != "tools[CustomLookup]" {
+		t.Errorf("kiro-cli warnings = %v, want one passthrough warning", kiro)
+	}
+
+	agentkit := AgentPlatformCompatibility(agent, PlatformAgentKitLocal)
+	if len(agentkit) != 3 {
+		t.Fatalf("agentkit-local warnings = %v, want 3", agentkit)
+	}
+	if !strings.Contains(agentkit[0].Message, "WebSearch on agentkit-local runs through the generic shell tool") {
+		t.Errorf("agentkit[0] = %q", agentkit[0].Message)
+	}
+	if !strings.Contains(agentkit[2].Message, "passed through unchanged") {
+		t.Errorf("agentkit[2] = %q", agentkit[2].Message)
+	}
+}
+
+func TestValidateInstructionToolMentions(t *testing.T) {
+	agent := NewAgent("a", "").WithTools("Read", "Grep").WithInstructions(
+		"Read the task carefully. Use the Grep tool to find call sites, then " +
+			"fetch docs with WebFetch. Apply fixes with the Edit tool and run `Bash`.")
+
+	warnings := agent.ValidateInstructionToolMentions()
+	var got []string
+	for _, w := range warnings {
+		got = append(got, w.Message)
+	}
+	want := []string{
+		"instructions mention the WebFetch tool but it is not in tools",
+		"instructions mention the Bash tool but it is not in tools",
+		"instructions mention the Edit tool but it is not in tools",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("warnings =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	agent.WithTools("Read", "Grep", "WebFetch", "Edit", "Bash")
+	if warnings := agent.ValidateInstructionToolMentions(); len(warnings) != 0 {
+		t.Errorf("warnings = %v, want none", warnings)
+	}
+}
+
+func TestValidateModelForTasks(t *testing.T) {
+	orchestrator := NewAgent("lead", "").WithModel(ModelHaiku).WithTools("task")
+	var commands []Task
+	for i := 0; i < heavyCommandTasks; i++ {
+		commands = append(commands, Task{ID: fmt.Sprintf("c%d", i), Type: TaskTypeCommand, Command: "true"})
+	}
+	runner := NewAgent("runner", "").WithModel(ModelHaiku)
+	runner.Tasks = commands
+
+	tests := []struct {
+		agent *Agent
+		want  []string
+	}{
+		{orchestrator, []string{"model: agent orchestrates sub-agents with the Task tool but uses haiku; use at least sonnet"}},
+		{runner, []string{fmt.Sprintf("model: agent runs %d command tasks but uses haiku; use at least sonnet", heavyCommandTasks)}},
+		{NewAgent("lead", "").WithTools("Task"), nil},
+		{NewAgent("lead", "").WithModel("gpt-4o").WithTools("Task"), nil},
+		{NewAgent("small", "").WithModel(ModelHaiku).WithTools("Read"), nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, w := range tt.agent.ValidateModelForTasks() {
+			got = append(got, w.String())
+		}
+		if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("%s: ValidateModelForTasks() = %v, want %v", tt.agent.Name, got, tt.want)
+		}
+	}
+}
+
+func TestWorkflowLintRequiredDefault(t *testing.T) {
+	yes, no := true, false
+	w := &Workflow{Steps: []Step{{
+		Name:  "search",
+		Agent: "a",
+		Inputs: []Port{
+			{Name: "query", Required: &yes, Default: "go"},
+			{Name: "limit", Required: &no, Default: 10},
+			{Name: "topic", Required: &yes},
+			{Name: "lang", Default: "en"},
+		},
+	}}}
+	got := w.Lint()
+	want := "steps[0].inputs[0].required: input query is required but has a default, so required has no effect"
+	if len(got) != 1 || got[0].String() != want {
+		t.Errorf("Lint() = %v, want [%s]", got, want)
+	}
+}
+
+func TestValidateToolCoverage(t *testing.T) {
+	writer := NewAgent("writer", "").WithTools("web_search", "Read", "Notebook", "lookup")
+	writer.CustomTools = []CustomTool{{Name: "lookup"}}
+	agents := []*Agent{writer, NewAgent("reader", "").WithTools("Grep")}
+
+	var got []string
+	for _, w := range ValidateToolCoverage(agents, PlatformClaudeCode) {
+		got = append(got, w.String())
+	}
+	want := "agents[writer].tools[Notebook]: Notebook has no mapping on claude-code and will be passed through unchanged"
+	if strings.Join(got, "\n") != want {
+		t.Errorf("ValidateToolCoverage(claude-code) = %v, want [%s]", got, want)
+	}
+
+	saved := KiroCLITools[ToolGrep]
+	delete(KiroCLITools, ToolGrep)
+	defer func() { KiroCLITools[ToolGrep] = saved }()
+
+	got = nil
+	for _, w := range ValidateToolCoverage(agents, PlatformKiroCLI) {
+		got = append(got, w.Path)
+	}
+	if strings.Join(got, ",") != "agents[writer].tools[Notebook],agents[reader].tools[Grep]" {
+		t.Errorf("ValidateToolCoverage(kiro-cli) paths = %v", got)
+	}
+}
@@ -51,6 +51,24 @@ func ParseAgentMarkdown(data []byte) (*Agent, error) {
 		return nil, fmt.Errorf("parse yaml: %w", err)
 	}
 
+	// OutputSchema is JSON, so it is decoded separately and converted.
+	var extra struct {
+		OutputSchema interface{} `yaml:"outputSchema"`
+	}
+	if err := yaml.Unmarshal(frontmatter, &extra); err != nil {
+		return nil, fmt.Errorf("parse yaml: %w", err)
+	}
+	if extra.OutputSchema != nil {
+		schema, err := json.Marshal(extra.OutputSchema)
+		if err != nil {
+			return nil, fmt.Errorf("parse outputSchema: %w", err)
+		}
+		if err := validateSchemaSyntax(schema); err != nil {
+			return nil, fmt.Errorf("parse outputSchema: %w", err)
+		}
+		agent.OutputSchema = schema
+	}
+
 	// Set instructions from markdown body
 	agent.Instructions = strings.TrimSpace(string(body))
 
@@ -147,7 +165,8 @@ func LoadAgentsFromDirFlat(dir string) ([]*Agent, error) {
 	return agents, nil
 }
 
-// LoadTeamFromFile loads a Team from a JSON file.
+// LoadTeamFromFile loads a Team from a JSON file. The team version, if set,
+// is normalized with NormalizeVersion.
 func LoadTeamFromFile(path string) (*Team, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -159,6 +178,20 @@ func LoadTeamFromFile(path string) (*Team, error) {
 		return nil, fmt.Errorf("parse json: %w", err)
 	}
 
+	if team.Version != "" {
+		version, err := NormalizeVersion(team.Version)
+		if err != nil {
+			return nil, fmt.Errorf("normalize version: %w", err)
+		}
+		team.Version = version
+	}
+
+	if team.Workflow != nil {
+		if err := team.Workflow.Validate(); err != nil {
+			return nil, fmt.Errorf("validate workflow: %w", err)
+		}
+	}
+
 	return &team, nil
 }
 
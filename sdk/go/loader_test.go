@@ -3,6 +3,7 @@ package multiagentspec
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -86,6 +87,35 @@ Follow these steps:
 	}
 }
 
+func TestParseAgentMarkdownOutputSchema(t *testing.T) {
+	md := `---
+name: summarizer
+outputSchema:
+  type: object
+  required: [summary]
+  properties:
+    summary:
+      type: string
+---
+
+Summarize.`
+	agent, err := ParseAgentMarkdown([]byte(md))
+	if err != nil {
+		t.Fatalf("ParseAgentMarkdown failed: %v", err)
+	}
+	if err := agent.ValidateOutput([]byte(`{"summary": "ok"}`)); err != nil {
+		t.Errorf("ValidateOutput = %v", err)
+	}
+	if err := agent.ValidateOutput([]byte(`{}`)); err == nil {
+		t.Error("ValidateOutput should fail without summary")
+	}
+
+	bad := "---\nname: a\noutputSchema:\n  type: text\n---\n"
+	if _, err := ParseAgentMarkdown([]byte(bad)); err == nil || !strings.Contains(err.Error(), `parse outputSchema: #/type: unknown type "text"`) {
+		t.Errorf("ParseAgentMarkdown error = %v", err)
+	}
+}
+
 func TestLoadAgentsFromDir(t *testing.T) {
 	// Create temp directory with test files
 	tmpDir := t.TempDir()
@@ -208,6 +238,57 @@ func TestLoadTeamFromFile(t *testing.T) {
 	}
 }
 
+func TestLoadTeamFromFileInvalidTransform(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	teamJSON := `{
+  "name": "test-team",
+  "version": "1.0.0",
+  "agents": ["a"],
+  "workflow": {
+    "steps": [
+      {"name": "s", "agent": "a", "inputs": [{"name": "in", "transform": "results[0]"}]}
+    ]
+  }
+}`
+
+	path := filepath.Join(tmpDir, "team.json")
+	if err := os.WriteFile(path, []byte(teamJSON), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadTeamFromFile(path)
+	if err == nil {
+		t.Fatal("LoadTeamFromFile should fail for invalid transform")
+	}
+	if !strings.Contains(err.Error(), "steps[0]: port in: invalid transform") {
+		t.Errorf("error = %q", err)
+	}
+}
+
+func TestLoadTeamFromFileNormalizesVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "team.json")
+
+	if err := os.WriteFile(path, []byte(`{"name": "t", "version": "v2.1.0", "agents": []}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	team, err := LoadTeamFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadTeamFromFile failed: %v", err)
+	}
+	if team.Version != "2.1.0" {
+		t.Errorf("Version = %q, want %q", team.Version, "2.1.0")
+	}
+
+	if err := os.WriteFile(path, []byte(`{"name": "t", "version": "latest", "agents": []}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadTeamFromFile(path); err == nil || !strings.Contains(err.Error(), `normalize version: invalid version "latest"`) {
+		t.Errorf("LoadTeamFromFile error = %v", err)
+	}
+}
+
 func TestLoadAgentsFromDirNested(t *testing.T) {
 	// Create temp directory with nested structure
 	tmpDir := t.TempDir()
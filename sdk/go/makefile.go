@@ -0,0 +1,158 @@
+package multiagentspec
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// makeReservedTargets are the Makefile rules ToMakefile always emits, which
+// deployment targets may not be named after.
+var makeReservedTargets = map[string]bool{"all": true, "check-binaries": true}
+
+// makefileRecipeData is the data available to the recipe template of
+// ToMakefile.
+type makefileRecipeData struct {
+	Name     string
+	Platform Platform
+	Output   string
+	Team     string
+}
+
+// makefileRecipe is the recipe of the rules emitted by ToMakefile.
+const makefileRecipe = "$(GENERATE) {{.Name}} {{.Platform}} {{if .Output}}{{.Output}}{{else}}.{{end}}"
+
+// makefileTargetName matches the target names ToMakefile accepts, which are
+// safe to use unquoted in make rules and shell recipes.
+var makefileTargetName = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// ToMakefile renders the deployment as a Makefile with one rule per target,
+// named after Target.Name, that runs $(GENERATE) with the target name,
+// platform, and Output directory (or ".") as arguments. GENERATE has no
+// default and must be set on the make command line to the command that
+// generates one target (e.g., "make GENERATE=./bin/generate"). The "all"
+// rule builds every target, and targets are ordered by priority: each target
+// depends on the targets of the nearest higher priority present (p1 targets
+// are prerequisites of p2 targets, and so on; unset priority comes last).
+// When agents, the resolved team agents, require binaries (see
+// RequiredBinaries), every target also depends on a check-binaries rule that
+// fails if one is missing. See ToMakefileWithRecipe for the errors returned.
+func (d *Deployment) ToMakefile(agents []*Agent) ([]byte, error) {
+	preamble := "ifndef GENERATE\n$(error GENERATE must be set to the command that generates one target)\nendif\n\n"
+	return d.toMakefile(agents, makefileRecipe, preamble)
+}
+
+// ToMakefileWithRecipe is like ToMakefile, but renders each rule's recipe
+// from recipeTemplate instead of running $(GENERATE). recipeTemplate is a
+// text/template with the fields .Name, .Platform, .Output, and .Team, where
+// .Output and .Team are quoted for the shell; for example
+// "./bin/generate -target {{.Name}}{{if .Output}} -out {{.Output}}{{end}}".
+// It must render to a single line. It returns an error if recipeTemplate is
+// empty or fails to render, if a target name is not made of letters, digits,
+// ".", "_", and "-", is used twice, or clashes with a built-in rule, or if a
+// target's platform is unknown.
+func (d *Deployment) ToMakefileWithRecipe(agents []*Agent, recipeTemplate string) ([]byte, error) {
+	return d.toMakefile(agents, recipeTemplate, "")
+}
+
+// toMakefile renders the Makefile of ToMakefileWithRecipe, writing preamble
+// after the header comment.
+func (d *Deployment) toMakefile(agents []*Agent, recipeTemplate, preamble string) ([]byte, error) {
+	if strings.TrimSpace(recipeTemplate) == "" {
+		return nil, fmt.Errorf("makefile: recipe template is required")
+	}
+	tmpl, err := template.New("recipe").Option("missingkey=error").Parse(recipeTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("makefile: parse recipe template: %w", err)
+	}
+
+	seen := make(map[string]bool, len(d.Targets))
+	for _, t := range d.Targets {
+		switch {
+		case !makefileTargetName.MatchString(t.Name):
+			return nil, fmt.Errorf("makefile: target name %q is not a valid make target", t.Name)
+		case !t.Platform.Valid():
+			return nil, fmt.Errorf("makefile: target %s: unknown platform %q", t.Name, t.Platform)
+		case makeReservedTargets[t.Name]:
+			return nil, fmt.Errorf("makefile: target name %q clashes with a built-in rule", t.Name)
+		case seen[t.Name]:
+			return nil, fmt.Errorf("makefile: duplicate target name %q", t.Name)
+		}
+		seen[t.Name] = true
+	}
+
+	ordered := d.OrderedTargets()
+	names := make([]string, len(ordered))
+	for i, t := range ordered {
+		names[i] = t.Name
+	}
+	binaries := d.RequiredBinaries(agents)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Deployment targets for team %s.\n", d.Team)
+	b.WriteString("# Generated by multi-agent-spec; do not edit.\n\n")
+	b.WriteString(preamble)
+
+	phony := append([]string{"all"}, names...)
+	if len(binaries) > 0 {
+		phony = append(phony, "check-binaries")
+	}
+	fmt.Fprintf(&b, ".PHONY: %s\n\n", strings.Join(phony, " "))
+	fmt.Fprintf(&b, "all: %s\n", strings.Join(names, " "))
+
+	if len(binaries) > 0 {
+		b.WriteString("\ncheck-binaries:\n")
+		for _, bin := range binaries {
+			fmt.Fprintf(&b, "\t@command -v %s >/dev/null 2>&1 || { echo \"missing required binary: %s\" >&2; exit 1; }\n",
+				shellQuote(bin), makefileEchoEscaper.Replace(bin))
+		}
+	}
+
+	// Group targets into consecutive priority tiers; each tier depends on
+	// the tier before it.
+	var previous, tier []string
+	for i, t := range ordered {
+		if i > 0 && t.Priority.rank() != ordered[i-1].Priority.rank() {
+			previous, tier = tier, nil
+		}
+		tier = append(tier, t.Name)
+
+		prereqs := append([]string(nil), previous...)
+		if len(binaries) > 0 {
+			prereqs = append([]string{"check-binaries"}, prereqs...)
+		}
+		fmt.Fprintf(&b, "\n%s:", t.Name)
+		if len(prereqs) > 0 {
+			b.WriteString(" " + strings.Join(prereqs, " "))
+		}
+		var recipe bytes.Buffer
+		data := makefileRecipeData{Name: t.Name, Platform: t.Platform, Team: shellQuote(d.Team)}
+		if t.Output != "" {
+			data.Output = shellQuote(t.Output)
+		}
+		if err := tmpl.Execute(&recipe, data); err != nil {
+			return nil, fmt.Errorf("makefile: target %s: render recipe template: %w", t.Name, err)
+		}
+		if strings.ContainsAny(recipe.String(), "\r\n") {
+			return nil, fmt.Errorf("makefile: target %s: recipe template must render to a single line", t.Name)
+		}
+		fmt.Fprintf(&b, "\n\t%s\n", recipe.String())
+	}
+	return []byte(b.String()), nil
+}
+
+// makefileEchoEscaper escapes a string for use inside a double-quoted shell
+// string in a make recipe.
+var makefileEchoEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`", "$", `\$$`)
+
+// shellQuote quotes s for a POSIX shell, leaving simple words unquoted. Dollar
+// signs are doubled so that make passes them through to the shell.
+func shellQuote(s string) string {
+	s = strings.ReplaceAll(s, "$", "$$")
+	if s != "" && !strings.ContainsAny(s, " \t\n'\"\\`;&|<>()*?[]{}~!#") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
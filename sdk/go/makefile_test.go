@@ -0,0 +1,107 @@
+package multiagentspec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDeploymentToMakefile(t *testing.T) {
+	d := NewDeployment("stats").
+		AddTarget(Target{Name: "local", Platform: PlatformClaudeCode, Output: ".claude/agents"}).
+		AddTarget(Target{Name: "gke", Platform: PlatformGCPGKE, Priority: PriorityP2, Output: "deploy/gke"}).
+		AddTarget(Target{Name: "eks", Platform: PlatformAWSEKS, Priority: PriorityP1, Output: "deploy/my eks"}).
+		AddTarget(Target{Name: "aks", Platform: PlatformAzureAKS, Priority: PriorityP2})
+	agent := NewAgent("a", "")
+	agent.Requires = []string{"kubectl"}
+
+	const recipe = "./bin/generate -team {{.Team}} -target {{.Name}} -platform {{.Platform}}{{if .Output}} -out {{.Output}}{{end}}"
+	data, err := d.ToMakefileWithRecipe([]*Agent{agent}, recipe)
+	if err != nil {
+		t.Fatalf("ToMakefileWithRecipe failed: %v", err)
+	}
+	want := `# Deployment targets for team stats.
+# Generated by multi-agent-spec; do not edit.
+
+.PHONY: all eks aks gke local check-binaries
+
+all: eks aks gke local
+
+check-binaries:
+	@command -v kubectl >/dev/null 2>&1 || { echo "missing required binary: kubectl" >&2; exit 1; }
+
+eks: check-binaries
+	./bin/generate -team stats -target eks -platform aws-eks -out 'deploy/my eks'
+
+aks: check-binaries eks
+	./bin/generate -team stats -target aks -platform azure-aks
+
+gke: check-binaries eks
+	./bin/generate -team stats -target gke -platform gcp-gke -out deploy/gke
+
+local: check-binaries aks gke
+	./bin/generate -team stats -target local -platform claude-code -out .claude/agents
+`
+	if string(data) != want {
+		t.Errorf("ToMakefileWithRecipe() =\n%s\nwant\n%s", data, want)
+	}
+
+	plain, err := d.ToMakefileWithRecipe(nil, recipe)
+	if err != nil {
+		t.Fatalf("ToMakefileWithRecipe failed: %v", err)
+	}
+	if strings.Contains(string(plain), "check-binaries") || !strings.Contains(string(plain), "\neks:\n") {
+		t.Errorf("ToMakefileWithRecipe(nil) =\n%s", plain)
+	}
+}
+
+func TestDeploymentToMakefileInvalidNames(t *testing.T) {
+	for _, name := range []string{"all", "my target", "a:b", "", "a;rm", "`x`", "a|b", `"a"`} {
+		d := NewDeployment("t").AddTarget(Target{Name: name, Platform: PlatformClaudeCode})
+		if _, err := d.ToMakefile(nil); err == nil {
+			t.Errorf("ToMakefile should reject target name %q", name)
+		}
+	}
+	bad := NewDeployment("t").AddTarget(Target{Name: "local", Platform: "claude-code; rm -rf /"})
+	if _, err := bad.ToMakefile(nil); err == nil || !strings.Contains(err.Error(), "unknown platform") {
+		t.Errorf("ToMakefile() error = %v, want unknown platform error", err)
+	}
+
+	dup := NewDeployment("t").
+		AddTarget(Target{Name: "local", Platform: PlatformClaudeCode}).
+		AddTarget(Target{Name: "local", Platform: PlatformKiroCLI})
+	if _, err := dup.ToMakefile(nil); err == nil || !strings.Contains(err.Error(), "duplicate target name") {
+		t.Errorf("ToMakefile() error = %v, want duplicate target name error", err)
+	}
+}
+
+func TestDeploymentToMakefileRecipeTemplate(t *testing.T) {
+	d := NewDeployment("t").AddTarget(Target{Name: "local", Platform: PlatformClaudeCode})
+	for _, recipe := range []string{"", "{{.Name", "{{.Missing}}", "a\nb"} {
+		if _, err := d.ToMakefileWithRecipe(nil, recipe); err == nil {
+			t.Errorf("ToMakefileWithRecipe should reject recipe template %q", recipe)
+		}
+	}
+}
+
+func TestDeploymentToMakefileGenerate(t *testing.T) {
+	d := NewDeployment("t").
+		AddTarget(Target{Name: "local", Platform: PlatformClaudeCode}).
+		AddTarget(Target{Name: "eks", Platform: PlatformAWSEKS, Output: "deploy/my eks"})
+	agent := NewAgent("a", "")
+	agent.Requires = []string{"odd$bin"}
+
+	data, err := d.ToMakefile([]*Agent{agent})
+	if err != nil {
+		t.Fatalf("ToMakefile failed: %v", err)
+	}
+	for _, want := range []string{
+		"ifndef GENERATE\n$(error GENERATE must be set",
+		"\t$(GENERATE) local claude-code .\n",
+		"\t$(GENERATE) eks aws-eks 'deploy/my eks'\n",
+		`echo "missing required binary: odd\$$bin"`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("ToMakefile() missing %q:\n%s", want, data)
+		}
+	}
+}
@@ -1,5 +1,10 @@
 package multiagentspec
 
+import (
+	"sort"
+	"strings"
+)
+
 // ClaudeCodeModels maps canonical model names to Claude Code identifiers.
 var ClaudeCodeModels = map[Model]string{
 	ModelHaiku:  "haiku",
@@ -21,6 +26,14 @@ var BedrockModels = map[Model]string{
 	ModelOpus:   "anthropic.claude-3-opus-20240229-v1:0",
 }
 
+// OpenAIModels maps canonical model names to the OpenAI models of
+// comparable capability.
+var OpenAIModels = map[Model]string{
+	ModelHaiku:  "gpt-4o-mini",
+	ModelSonnet: "gpt-4o",
+	ModelOpus:   "gpt-4.1",
+}
+
 // KiroCLITools maps canonical tool names to Kiro CLI identifiers.
 var KiroCLITools = map[Tool]string{
 	ToolWebSearch: "web_search",
@@ -47,6 +60,81 @@ var AgentKitTools = map[Tool]string{
 	ToolTask:      "shell",
 }
 
+// DegradedToolMappings describes canonical tools whose mapping on a platform
+// loses fidelity, keyed by platform. Each value explains the degradation.
+var DegradedToolMappings = map[Platform]map[Tool]string{
+	PlatformAgentKitLocal: {
+		ToolWebSearch: "runs through the generic shell tool",
+		ToolWebFetch:  "runs through the generic shell tool",
+		ToolTask:      "runs through the generic shell tool without native sub-agent delegation",
+		ToolEdit:      "is folded into write, so edits rewrite whole files",
+	},
+}
+
+// PlatformUnsupportedTools lists canonical tools that a platform cannot
+// provide at all, keyed by platform. Generators should warn about or drop them.
+var PlatformUnsupportedTools = map[Platform][]Tool{
+	// AgentKit local has no sub-agents to delegate to; Task only falls back
+	// to the shell tool (see AgentKitTools).
+	PlatformAgentKitLocal: {ToolTask},
+}
+
+// UnsupportedToolsOn returns the agent's tools that PlatformUnsupportedTools
+// lists for platform p, in Tools order and without duplicates.
+func (a *Agent) UnsupportedToolsOn(p Platform) []Tool {
+	unsupported := PlatformUnsupportedTools[p]
+	if len(unsupported) == 0 {
+		return nil
+	}
+	var result []Tool
+	seen := make(map[Tool]bool)
+	for _, name := range a.Tools {
+		tool, ok := CanonicalTool(name)
+		if !ok || seen[tool] {
+			continue
+		}
+		seen[tool] = true
+		for _, u := range unsupported {
+			if tool == u {
+				result = append(result, tool)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// CanonicalTool returns the canonical Tool for a tool name, ignoring case,
+// underscores, and hyphens, so "web_search", "WebSearch", and "websearch" all
+// resolve to ToolWebSearch. It returns false for unrecognized names.
+func CanonicalTool(name string) (Tool, bool) {
+	key := normalizeToolName(name)
+	for _, tool := range canonicalTools {
+		if normalizeToolName(string(tool)) == key {
+			return tool, true
+		}
+	}
+	return "", false
+}
+
+// normalizeToolName lowercases name and strips underscores and hyphens.
+func normalizeToolName(name string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))
+}
+
+// platformToolMap returns the tool mapping table for platforms that rename
+// canonical tools, or nil for platforms that use canonical names directly.
+func platformToolMap(p Platform) map[Tool]string {
+	switch p {
+	case PlatformKiroCLI:
+		return KiroCLITools
+	case PlatformAgentKitLocal:
+		return AgentKitTools
+	default:
+		return nil
+	}
+}
+
 // MapModelToClaudeCode converts a canonical model to Claude Code format.
 func MapModelToClaudeCode(model Model) string {
 	if mapped, ok := ClaudeCodeModels[model]; ok {
@@ -71,6 +159,14 @@ func MapModelToBedrock(model Model) string {
 	return string(model)
 }
 
+// MapModelToOpenAI converts a canonical model to an OpenAI model identifier.
+func MapModelToOpenAI(model Model) string {
+	if mapped, ok := OpenAIModels[model]; ok {
+		return mapped
+	}
+	return string(model)
+}
+
 // MapToolToKiroCLI converts a canonical tool to Kiro CLI format.
 func MapToolToKiroCLI(tool Tool) string {
 	if mapped, ok := KiroCLITools[tool]; ok {
@@ -86,3 +182,33 @@ func MapToolToAgentKit(tool Tool) string {
 	}
 	return string(tool)
 }
+
+// MapToolsToKiroCLI converts canonical tools to Kiro CLI format, returning a
+// sorted list without duplicates.
+func MapToolsToKiroCLI(tools []Tool) []string {
+	return mapTools(tools, MapToolToKiroCLI)
+}
+
+// MapToolsToAgentKit converts canonical tools to AgentKit local format,
+// returning a sorted list without duplicates. Several canonical tools map to
+// "shell", which appears only once in the result.
+func MapToolsToAgentKit(tools []Tool) []string {
+	return mapTools(tools, MapToolToAgentKit)
+}
+
+// mapTools applies mapTool to each tool and returns the sorted, deduplicated
+// result.
+func mapTools(tools []Tool, mapTool func(Tool) string) []string {
+	seen := make(map[string]bool, len(tools))
+	mapped := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		name := mapTool(tool)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		mapped = append(mapped, name)
+	}
+	sort.Strings(mapped)
+	return mapped
+}
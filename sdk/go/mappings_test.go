@@ -1,6 +1,7 @@
 package multiagentspec
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -267,3 +268,44 @@ func TestMappingCompleteness(t *testing.T) {
 		}
 	}
 }
+
+func TestMapToolsToAgentKit(t *testing.T) {
+	got := MapToolsToAgentKit([]Tool{ToolBash, ToolWebSearch, ToolRead, ToolTask, ToolEdit, ToolWrite})
+	want := []string{"read", "shell", "write"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MapToolsToAgentKit() = %v, want %v", got, want)
+	}
+	if got := MapToolsToAgentKit(nil); len(got) != 0 {
+		t.Errorf("MapToolsToAgentKit(nil) = %v, want empty", got)
+	}
+}
+
+func TestMapToolsToKiroCLI(t *testing.T) {
+	got := MapToolsToKiroCLI([]Tool{ToolRead, ToolBash, ToolRead, "CustomTool"})
+	want := []string{"CustomTool", "bash", "read"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MapToolsToKiroCLI() = %v, want %v", got, want)
+	}
+}
+
+func TestCanonicalTool(t *testing.T) {
+	for _, name := range []string{"WebSearch", "web_search", "websearch", "Web-Search"} {
+		if got, ok := CanonicalTool(name); !ok || got != ToolWebSearch {
+			t.Errorf("CanonicalTool(%q) = %q, %v", name, got, ok)
+		}
+	}
+	if _, ok := CanonicalTool("query_database"); ok {
+		t.Error("CanonicalTool should not recognize query_database")
+	}
+}
+
+func TestAgentUnsupportedToolsOn(t *testing.T) {
+	agent := NewAgent("a", "").WithTools("Read", "task", "Task")
+	got := agent.UnsupportedToolsOn(PlatformAgentKitLocal)
+	if !reflect.DeepEqual(got, []Tool{ToolTask}) {
+		t.Errorf("UnsupportedToolsOn(agentkit-local) = %v, want [Task]", got)
+	}
+	if got := agent.UnsupportedToolsOn(PlatformClaudeCode); got != nil {
+		t.Errorf("UnsupportedToolsOn(claude-code) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,60 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToDoc renders human-readable markdown documentation for the agent: its
+// description, model, a table of tools, dependencies, and tasks.
+func (a *Agent) ToDoc() []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", a.QualifiedName())
+	if a.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", a.Description)
+	}
+	fmt.Fprintf(&b, "**Model:** %s\n", a.effectiveModel())
+
+	if tools := a.AllTools(); len(tools) > 0 {
+		b.WriteString("\n## Tools\n\n")
+		b.WriteString("| Tool | Description |\n")
+		b.WriteString("|------|-------------|\n")
+		for _, tool := range tools {
+			fmt.Fprintf(&b, "| %s | %s |\n", escapeTableCell(tool), escapeTableCell(a.toolDescription(tool)))
+		}
+	}
+
+	if len(a.Dependencies) > 0 {
+		b.WriteString("\n## Dependencies\n\n")
+		for _, dep := range a.Dependencies {
+			fmt.Fprintf(&b, "- %s\n", dep)
+		}
+	}
+
+	if len(a.Tasks) > 0 {
+		b.WriteString("\n## Tasks\n")
+		for _, task := range a.Tasks {
+			fmt.Fprintf(&b, "\n### %s\n\n", task.ID)
+			if task.Description != "" {
+				fmt.Fprintf(&b, "%s\n\n", task.Description)
+			}
+			taskType := task.Type
+			if taskType == "" {
+				taskType = TaskTypeManual
+			}
+			fmt.Fprintf(&b, "- **Type:** %s\n", taskType)
+			if task.ExpectedOutput != "" {
+				fmt.Fprintf(&b, "- **Expected output:** %s\n", task.ExpectedOutput)
+			}
+		}
+	}
+
+	return []byte(b.String())
+}
+
+// escapeTableCell makes s safe to place inside a markdown table cell.
+func escapeTableCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,58 @@
+package multiagentspec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAgentToDoc(t *testing.T) {
+	agent := NewAgent("reviewer", "Reviews pull requests.").
+		WithModel(ModelOpus).
+		WithTools("Read", "Grep", "Jira|Lookup")
+	agent.Dependencies = []string{"linter"}
+	agent.Tasks = []Task{
+		{ID: "lint", Description: "Run the linter", Type: TaskTypeCommand, ExpectedOutput: "No findings"},
+		{ID: "review"},
+	}
+
+	doc := string(agent.ToDoc())
+
+	for _, want := range []string{
+		"# reviewer\n\nReviews pull requests.\n\n**Model:** opus\n",
+		"| Read | Read file contents |\n",
+		"| Grep | Search file contents with regular expressions |\n",
+		`| Jira\|Lookup |  |`,
+		"## Dependencies\n\n- linter\n",
+		"### lint\n\nRun the linter\n\n- **Type:** command\n- **Expected output:** No findings\n",
+		"### review\n\n- **Type:** manual\n",
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("ToDoc() missing %q:\n%s", want, doc)
+		}
+	}
+}
+
+func TestAgentToDocMinimal(t *testing.T) {
+	doc := string((&Agent{Name: "bare"}).ToDoc())
+	if doc != "# bare\n\n**Model:** sonnet\n" {
+		t.Errorf("ToDoc() = %q", doc)
+	}
+}
+
+func TestToolDescriptionsComplete(t *testing.T) {
+	for _, tool := range []Tool{ToolWebSearch, ToolWebFetch, ToolRead, ToolWrite, ToolGlob, ToolGrep, ToolBash, ToolEdit, ToolTask} {
+		if ToolDescriptions[tool] == "" {
+			t.Errorf("ToolDescriptions[%s] is empty", tool)
+		}
+	}
+}
+
+func TestAgentToDocCustomTools(t *testing.T) {
+	agent := NewAgent("a", "").WithTools("Read")
+	agent.CustomTools = []CustomTool{{Name: "jira_lookup", Description: "Look up a Jira issue"}}
+
+	doc := string(agent.ToDoc())
+	if !strings.Contains(doc, "| jira_lookup | Look up a Jira issue |") {
+		t.Errorf("ToDoc() missing custom tool row:\n%s", doc)
+	}
+}
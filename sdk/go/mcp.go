@@ -0,0 +1,75 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// mcpToolAliases maps tool names commonly exposed by MCP servers, normalized
+// with normalizeToolName, to canonical tools.
+var mcpToolAliases = map[string]Tool{
+	"readfile":     ToolRead,
+	"writefile":    ToolWrite,
+	"editfile":     ToolEdit,
+	"fetch":        ToolWebFetch,
+	"fetchurl":     ToolWebFetch,
+	"search":       ToolWebSearch,
+	"searchfiles":  ToolGlob,
+	"shell":        ToolBash,
+	"runcommand":   ToolBash,
+	"executeshell": ToolBash,
+}
+
+// mcpTool is a tool entry in an MCP tools/list result.
+type mcpTool struct {
+	Name string `json:"name"`
+}
+
+// mcpToolList is the body of an MCP tools/list result.
+type mcpToolList struct {
+	Tools []mcpTool `json:"tools"`
+}
+
+// ToolsFromMCPManifest parses an MCP server's tool list and maps each tool
+// to a canonical Tool. The manifest may be a tools/list result
+// ({"tools": [...]}) or the full JSON-RPC response wrapping one in "result".
+// Names are matched with CanonicalTool and a table of common MCP tool names
+// (e.g., read_file, fetch); unrecognized names are returned unchanged in
+// passthrough. Both lists keep manifest order and contain no duplicates.
+func ToolsFromMCPManifest(manifest []byte) (canonical []Tool, passthrough []string, err error) {
+	var doc struct {
+		mcpToolList
+		Result *mcpToolList `json:"result"`
+	}
+	if err := json.Unmarshal(manifest, &doc); err != nil {
+		return nil, nil, fmt.Errorf("parse mcp manifest: %w", err)
+	}
+	list := doc.Tools
+	if doc.Result != nil {
+		list = doc.Result.Tools
+	}
+
+	seenTools := make(map[Tool]bool)
+	seenNames := make(map[string]bool)
+	for i, t := range list {
+		if t.Name == "" {
+			return nil, nil, fmt.Errorf("parse mcp manifest: tools[%d]: name is required", i)
+		}
+		tool, ok := CanonicalTool(t.Name)
+		if !ok {
+			tool, ok = mcpToolAliases[normalizeToolName(t.Name)]
+		}
+		if !ok {
+			if !seenNames[t.Name] {
+				seenNames[t.Name] = true
+				passthrough = append(passthrough, t.Name)
+			}
+			continue
+		}
+		if !seenTools[tool] {
+			seenTools[tool] = true
+			canonical = append(canonical, tool)
+		}
+	}
+	return canonical, passthrough, nil
+}
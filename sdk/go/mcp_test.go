@@ -0,0 +1,49 @@
+package multiagentspec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToolsFromMCPManifest(t *testing.T) {
+	manifest := []byte(`{
+		"tools": [
+			{"name": "read_file", "description": "Read a file", "inputSchema": {"type": "object"}},
+			{"name": "web_search"},
+			{"name": "Grep"},
+			{"name": "query_database"},
+			{"name": "readFile"}
+		]
+	}`)
+
+	canonical, passthrough, err := ToolsFromMCPManifest(manifest)
+	if err != nil {
+		t.Fatalf("ToolsFromMCPManifest failed: %v", err)
+	}
+	if want := []Tool{ToolRead, ToolWebSearch, ToolGrep}; !reflect.DeepEqual(canonical, want) {
+		t.Errorf("canonical = %v, want %v", canonical, want)
+	}
+	if want := []string{"query_database"}; !reflect.DeepEqual(passthrough, want) {
+		t.Errorf("passthrough = %v, want %v", passthrough, want)
+	}
+}
+
+func TestToolsFromMCPManifestJSONRPC(t *testing.T) {
+	manifest := []byte(`{"jsonrpc":"2.0","id":1,"result":{"tools":[{"name":"fetch"},{"name":"run_command"}]}}`)
+
+	canonical, passthrough, err := ToolsFromMCPManifest(manifest)
+	if err != nil {
+		t.Fatalf("ToolsFromMCPManifest failed: %v", err)
+	}
+	if want := []Tool{ToolWebFetch, ToolBash}; !reflect.DeepEqual(canonical, want) || passthrough != nil {
+		t.Errorf("ToolsFromMCPManifest() = %v, %v", canonical, passthrough)
+	}
+}
+
+func TestToolsFromMCPManifestErrors(t *testing.T) {
+	for _, manifest := range []string{`not json`, `{"tools":[{"description":"nameless"}]}`} {
+		if _, _, err := ToolsFromMCPManifest([]byte(manifest)); err == nil {
+			t.Errorf("ToolsFromMCPManifest(%s) should fail", manifest)
+		}
+	}
+}
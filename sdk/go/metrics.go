@@ -0,0 +1,29 @@
+package multiagentspec
+
+import "strconv"
+
+// MetricLabels returns the standard metric labels for the agent: "agent",
+// "model" (the effective model), "tool_count" (the number of AllTools), and
+// "namespace" when the agent has one. Values are valid Kubernetes label values
+// for conventional agent names, so generators can attach them to pods.
+func (a *Agent) MetricLabels() map[string]string {
+	labels := map[string]string{
+		"agent":      a.Name,
+		"model":      string(a.effectiveModel()),
+		"tool_count": strconv.Itoa(len(a.AllTools())),
+	}
+	if a.Namespace != "" {
+		labels["namespace"] = a.Namespace
+	}
+	return labels
+}
+
+// MetricLabels returns the standard metric labels for the team: "team",
+// "team_version", and "agent_count".
+func (t *Team) MetricLabels() map[string]string {
+	return map[string]string{
+		"team":         t.Name,
+		"team_version": t.Version,
+		"agent_count":  strconv.Itoa(len(t.Agents)),
+	}
+}
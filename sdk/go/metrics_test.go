@@ -0,0 +1,29 @@
+package multiagentspec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAgentMetricLabels(t *testing.T) {
+	agent := NewAgent("research", "").WithTools("Read", "Grep")
+	agent.CustomTools = []CustomTool{{Name: "lookup"}}
+	want := map[string]string{"agent": "research", "model": "sonnet", "tool_count": "3"}
+	if got := agent.MetricLabels(); !reflect.DeepEqual(got, want) {
+		t.Errorf("MetricLabels() = %v, want %v", got, want)
+	}
+
+	agent.WithNamespace("prd").WithModel(ModelOpus)
+	got := agent.MetricLabels()
+	if got["namespace"] != "prd" || got["model"] != "opus" {
+		t.Errorf("MetricLabels() = %v", got)
+	}
+}
+
+func TestTeamMetricLabels(t *testing.T) {
+	team := NewTeam("stats", "1.2.0").WithAgents("lead", "research")
+	want := map[string]string{"team": "stats", "team_version": "1.2.0", "agent_count": "2"}
+	if got := team.MetricLabels(); !reflect.DeepEqual(got, want) {
+		t.Errorf("MetricLabels() = %v, want %v", got, want)
+	}
+}
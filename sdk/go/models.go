@@ -0,0 +1,87 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Capability describes what a model tier can do.
+type Capability struct {
+	// ContextWindow is the maximum context size in tokens.
+	ContextWindow int `json:"contextWindow"`
+
+	// SupportsTools indicates the model can call tools.
+	SupportsTools bool `json:"supportsTools"`
+
+	// SupportsVision indicates the model accepts image inputs.
+	SupportsVision bool `json:"supportsVision"`
+}
+
+// ModelCapabilities maps canonical model tiers to their capabilities.
+var ModelCapabilities = map[Model]Capability{
+	ModelHaiku:  {ContextWindow: 200000, SupportsTools: true, SupportsVision: true},
+	ModelSonnet: {ContextWindow: 200000, SupportsTools: true, SupportsVision: true},
+	ModelOpus:   {ContextWindow: 200000, SupportsTools: true, SupportsVision: true},
+}
+
+// Capabilities returns the capabilities of the model tier.
+// It returns false if the model is not in ModelCapabilities.
+func (m Model) Capabilities() (Capability, bool) {
+	c, ok := ModelCapabilities[m]
+	return c, ok
+}
+
+// ModelAliases maps tier names from other ecosystems, in lowercase, to
+// canonical models. ParseModel consults it after the canonical names.
+var ModelAliases = map[string]Model{
+	"small":      ModelHaiku,
+	"mini":       ModelHaiku,
+	"fast":       ModelHaiku,
+	"flash-lite": ModelHaiku,
+	"medium":     ModelSonnet,
+	"balanced":   ModelSonnet,
+	"flash":      ModelSonnet,
+	"large":      ModelOpus,
+	"pro":        ModelOpus,
+}
+
+// ParseModel resolves s to a canonical model, ignoring case and surrounding
+// whitespace. It accepts the canonical names (haiku, sonnet, opus) and the
+// aliases in ModelAliases.
+func ParseModel(s string) (Model, error) {
+	key := strings.ToLower(strings.TrimSpace(s))
+	if m := Model(key); m.Valid() {
+		return m, nil
+	}
+	if m, ok := ModelAliases[key]; ok {
+		return m, nil
+	}
+	return "", fmt.Errorf("unknown model %q", s)
+}
+
+// CollectModels returns the distinct models used by agents, sorted by name.
+// Agents without a Model count as using ModelSonnet, the default.
+func CollectModels(agents []*Agent) []Model {
+	seen := make(map[Model]bool)
+	var result []Model
+	for _, a := range agents {
+		if a == nil {
+			continue
+		}
+		m := a.effectiveModel()
+		if seen[m] {
+			continue
+		}
+		seen[m] = true
+		result = append(result, m)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// estimateTokens roughly estimates the token count of s using the common
+// heuristic of four characters per token.
+func estimateTokens(s string) int {
+	return (len(s) + 3) / 4
+}
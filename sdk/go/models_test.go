@@ -0,0 +1,83 @@
+package multiagentspec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestModelCapabilities(t *testing.T) {
+	for _, m := range []Model{ModelHaiku, ModelSonnet, ModelOpus} {
+		c, ok := m.Capabilities()
+		if !ok {
+			t.Errorf("%s.Capabilities() not found", m)
+			continue
+		}
+		if c.ContextWindow <= 0 {
+			t.Errorf("%s ContextWindow = %d, want > 0", m, c.ContextWindow)
+		}
+		if !c.SupportsTools {
+			t.Errorf("%s SupportsTools = false, want true", m)
+		}
+	}
+
+	if _, ok := Model("gpt-2").Capabilities(); ok {
+		t.Error("unknown model should have no capabilities")
+	}
+}
+
+func TestParseModel(t *testing.T) {
+	tests := map[string]Model{
+		"haiku":  ModelHaiku,
+		"Sonnet": ModelSonnet,
+		" OPUS ": ModelOpus,
+		"small":  ModelHaiku,
+		"Medium": ModelSonnet,
+		"large":  ModelOpus,
+		"flash":  ModelSonnet,
+		"PRO":    ModelOpus,
+	}
+	for s, want := range tests {
+		got, err := ParseModel(s)
+		if err != nil || got != want {
+			t.Errorf("ParseModel(%q) = %q, %v; want %q", s, got, err, want)
+		}
+	}
+
+	if _, err := ParseModel("gigantic"); err == nil {
+		t.Error("ParseModel should reject unknown models")
+	}
+}
+
+func TestModelAliasesAreCanonical(t *testing.T) {
+	for alias, m := range ModelAliases {
+		if !m.Valid() {
+			t.Errorf("alias %q maps to non-canonical model %q", alias, m)
+		}
+		if alias != strings.ToLower(alias) {
+			t.Errorf("alias %q must be lowercase", alias)
+		}
+	}
+}
+
+func TestCollectModels(t *testing.T) {
+	agents := []*Agent{
+		NewAgent("a", "").WithModel(ModelOpus),
+		NewAgent("b", ""),
+		nil,
+		NewAgent("c", "").WithModel(ModelHaiku),
+		NewAgent("d", "").WithModel(ModelSonnet),
+	}
+	got := CollectModels(agents)
+	want := []Model{ModelHaiku, ModelOpus, ModelSonnet}
+	if len(got) != len(want) {
+		t.Fatalf("CollectModels() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("CollectModels()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+	if got := CollectModels(nil); len(got) != 0 {
+		t.Errorf("CollectModels(nil) = %v, want empty", got)
+	}
+}
@@ -0,0 +1,111 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// OpenAIAssistantTools maps canonical tools to the built-in OpenAI Assistants
+// API tool types that provide them. Canonical tools not listed here have no
+// Assistants equivalent.
+var OpenAIAssistantTools = map[Tool]string{
+	ToolBash: "code_interpreter",
+	ToolRead: "file_search",
+	ToolGrep: "file_search",
+}
+
+// openAIAssistant is an OpenAI Assistants API assistant creation request.
+type openAIAssistant struct {
+	Model        string          `json:"model"`
+	Name         string          `json:"name,omitempty"`
+	Description  string          `json:"description,omitempty"`
+	Instructions string          `json:"instructions,omitempty"`
+	Tools        []openAIToolDef `json:"tools"`
+}
+
+// openAIToolDef is an OpenAI Assistants API tool entry.
+type openAIToolDef struct {
+	Type     string              `json:"type"`
+	Function *openAIFunctionSpec `json:"function,omitempty"`
+}
+
+// openAIFunctionSpec describes a function tool.
+type openAIFunctionSpec struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters"`
+}
+
+// ToOpenAIAssistant renders the agent as the JSON body of an OpenAI
+// Assistants API create-assistant request. The agent's Model is mapped with
+// MapModelToOpenAI (OpenAI is not a Platform, so ModelByPlatform does not
+// apply), canonical tools become the built-in tool types in
+// OpenAIAssistantTools (each type emitted once), and custom tools become
+// function tools. Tools without an Assistants equivalent are omitted; see
+// OpenAIAssistantWarnings.
+func (a *Agent) ToOpenAIAssistant() ([]byte, error) {
+	custom := make(map[string]CustomTool, len(a.CustomTools))
+	for _, ct := range a.CustomTools {
+		custom[ct.Name] = ct
+	}
+
+	assistant := openAIAssistant{
+		Model:        MapModelToOpenAI(a.effectiveModel()),
+		Name:         a.Name,
+		Description:  a.Description,
+		Instructions: a.Instructions,
+		Tools:        []openAIToolDef{},
+	}
+	seen := make(map[string]bool)
+	for _, name := range a.AllTools() {
+		if ct, ok := custom[name]; ok {
+			params := ct.Schema
+			if len(params) == 0 {
+				params = emptyInputSchema
+			}
+			assistant.Tools = append(assistant.Tools, openAIToolDef{
+				Type:     "function",
+				Function: &openAIFunctionSpec{Name: ct.Name, Description: ct.Description, Parameters: params},
+			})
+			continue
+		}
+		tool, _ := CanonicalTool(name)
+		typ, ok := OpenAIAssistantTools[tool]
+		if !ok || seen[typ] {
+			continue
+		}
+		seen[typ] = true
+		assistant.Tools = append(assistant.Tools, openAIToolDef{Type: typ})
+	}
+
+	data, err := json.MarshalIndent(assistant, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("openai assistant: %w", err)
+	}
+	return data, nil
+}
+
+// OpenAIAssistantWarnings warns about each of the agent's tools that
+// ToOpenAIAssistant omits because it has no OpenAI Assistants equivalent.
+func (a *Agent) OpenAIAssistantWarnings() []LintWarning {
+	custom := make(map[string]bool, len(a.CustomTools))
+	for _, ct := range a.CustomTools {
+		custom[ct.Name] = true
+	}
+
+	var warnings []LintWarning
+	for _, name := range a.AllTools() {
+		if custom[name] {
+			continue
+		}
+		tool, _ := CanonicalTool(name)
+		if _, ok := OpenAIAssistantTools[tool]; ok {
+			continue
+		}
+		warnings = append(warnings, LintWarning{
+			Path:    fmt.Sprintf("tools[%s]", name),
+			Message: fmt.Sprintf("%s has no OpenAI Assistants equivalent and will be omitted", name),
+		})
+	}
+	return warnings
+}
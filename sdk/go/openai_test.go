@@ -0,0 +1,56 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestAgentToOpenAIAssistant(t *testing.T) {
+	agent := NewAgent("researcher", "Finds sources").
+		WithModel(ModelOpus).
+		WithTools("Bash", "Read", "Grep", "WebSearch")
+	agent.Instructions = "Find sources."
+	agent.CustomTools = []CustomTool{{Name: "lookup", Description: "Look up a record"}}
+
+	data, err := agent.ToOpenAIAssistant()
+	if err != nil {
+		t.Fatalf("ToOpenAIAssistant failed: %v", err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v\n%s", err, data)
+	}
+	want := map[string]interface{}{
+		"model":        "gpt-4.1",
+		"name":         "researcher",
+		"description":  "Finds sources",
+		"instructions": "Find sources.",
+		"tools": []interface{}{
+			map[string]interface{}{"type": "code_interpreter"},
+			map[string]interface{}{"type": "file_search"},
+			map[string]interface{}{"type": "function", "function": map[string]interface{}{
+				"name":        "lookup",
+				"description": "Look up a record",
+				"parameters":  map[string]interface{}{"type": "object"},
+			}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToOpenAIAssistant() =\n%s", data)
+	}
+
+	warnings := agent.OpenAIAssistantWarnings()
+	if len(warnings) != 1 || warnings[0].String() != "tools[WebSearch]: WebSearch has no OpenAI Assistants equivalent and will be omitted" {
+		t.Errorf("OpenAIAssistantWarnings() = %v", warnings)
+	}
+}
+
+func TestMapModelToOpenAI(t *testing.T) {
+	if got := MapModelToOpenAI(ModelSonnet); got != "gpt-4o" {
+		t.Errorf("MapModelToOpenAI(sonnet) = %q", got)
+	}
+	if got := MapModelToOpenAI("gpt-4o-mini"); got != "gpt-4o-mini" {
+		t.Errorf("MapModelToOpenAI(gpt-4o-mini) = %q", got)
+	}
+}
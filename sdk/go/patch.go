@@ -0,0 +1,141 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// patchOp is a single RFC 6902 JSON Patch operation.
+type patchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// DiffAgents returns an RFC 6902 JSON Patch that transforms the JSON encoding
+// of a into that of b. Objects are diffed key by key, in sorted key order;
+// arrays that differ are replaced as a whole. Identical agents yield "[]".
+func DiffAgents(a, b *Agent) (json.RawMessage, error) {
+	if a == nil || b == nil {
+		return nil, fmt.Errorf("diff agents: agent is nil")
+	}
+	from, err := toJSONValue(a)
+	if err != nil {
+		return nil, fmt.Errorf("diff agents: %w", err)
+	}
+	to, err := toJSONValue(b)
+	if err != nil {
+		return nil, fmt.Errorf("diff agents: %w", err)
+	}
+
+	ops := []patchOp{}
+	if err := diffJSON(&ops, "", from, to); err != nil {
+		return nil, fmt.Errorf("diff agents: %w", err)
+	}
+	return json.Marshal(ops)
+}
+
+// toJSONValue converts v to its generic JSON representation.
+func toJSONValue(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// diffJSON appends the operations turning from into to at path.
+func diffJSON(ops *[]patchOp, path string, from, to interface{}) error {
+	if reflect.DeepEqual(from, to) {
+		return nil
+	}
+
+	fromObj, fromIsObj := from.(map[string]interface{})
+	toObj, toIsObj := to.(map[string]interface{})
+	if !fromIsObj || !toIsObj {
+		return appendPatchOp(ops, "replace", path, to)
+	}
+
+	keys := make([]string, 0, len(fromObj)+len(toObj))
+	for k := range fromObj {
+		keys = append(keys, k)
+	}
+	for k := range toObj {
+		if _, ok := fromObj[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		child := path + "/" + escapeJSONPointer(k)
+		fv, inFrom := fromObj[k]
+		tv, inTo := toObj[k]
+		var err error
+		switch {
+		case !inTo:
+			*ops = append(*ops, patchOp{Op: "remove", Path: child})
+		case !inFrom:
+			err = appendPatchOp(ops, "add", child, tv)
+		default:
+			err = diffJSON(ops, child, fv, tv)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendPatchOp appends an operation carrying value.
+func appendPatchOp(ops *[]patchOp, op, path string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	*ops = append(*ops, patchOp{Op: op, Path: path, Value: data})
+	return nil
+}
+
+// escapeJSONPointer escapes a reference token per RFC 6901.
+func escapeJSONPointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// ToolDiff returns the canonical tools granted in after but not before
+// (added) and those granted in before but not after (removed), in canonical
+// tool order. Tool names are compared after CanonicalTool normalization;
+// non-canonical tools are ignored. A nil agent has no tools.
+func ToolDiff(before, after *Agent) (added, removed []Tool) {
+	had, has := canonicalToolSet(before), canonicalToolSet(after)
+	for _, tool := range canonicalTools {
+		switch {
+		case has[tool] && !had[tool]:
+			added = append(added, tool)
+		case had[tool] && !has[tool]:
+			removed = append(removed, tool)
+		}
+	}
+	return added, removed
+}
+
+// canonicalToolSet returns the set of canonical tools in a.Tools.
+func canonicalToolSet(a *Agent) map[Tool]bool {
+	set := make(map[Tool]bool)
+	if a == nil {
+		return set
+	}
+	for _, name := range a.Tools {
+		if tool, ok := CanonicalTool(name); ok {
+			set[tool] = true
+		}
+	}
+	return set
+}
@@ -0,0 +1,73 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDiffAgents(t *testing.T) {
+	a := NewAgent("reviewer", "Reviews code").WithTools("Read")
+	a.Requires = []string{"git"}
+	a.Memory = &MemoryConfig{Type: MemoryEphemeral}
+
+	b := NewAgent("reviewer", "Reviews code").WithModel(ModelOpus).WithTools("Read", "Grep")
+	b.Instructions = "Be thorough."
+	b.Memory = &MemoryConfig{Type: MemoryPersistent, Backend: "redis"}
+
+	patch, err := DiffAgents(a, b)
+	if err != nil {
+		t.Fatalf("DiffAgents failed: %v", err)
+	}
+
+	want := `[` +
+		`{"op":"add","path":"/instructions","value":"Be thorough."},` +
+		`{"op":"add","path":"/memory/backend","value":"redis"},` +
+		`{"op":"replace","path":"/memory/type","value":"persistent"},` +
+		`{"op":"replace","path":"/model","value":"opus"},` +
+		`{"op":"remove","path":"/requires"},` +
+		`{"op":"replace","path":"/tools","value":["Read","Grep"]}` +
+		`]`
+	if string(patch) != want {
+		t.Errorf("DiffAgents() =\n%s\nwant\n%s", patch, want)
+	}
+
+	var ops []map[string]interface{}
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		t.Errorf("patch is not valid JSON: %v", err)
+	}
+}
+
+func TestDiffAgentsIdentical(t *testing.T) {
+	patch, err := DiffAgents(NewAgent("a", ""), NewAgent("a", ""))
+	if err != nil || string(patch) != "[]" {
+		t.Errorf("DiffAgents() = %s, %v; want []", patch, err)
+	}
+	if _, err := DiffAgents(nil, NewAgent("a", "")); err == nil {
+		t.Error("DiffAgents(nil, b) should fail")
+	}
+}
+
+func TestEscapeJSONPointer(t *testing.T) {
+	if got := escapeJSONPointer("a/b~c"); got != "a~1b~0c" {
+		t.Errorf("escapeJSONPointer() = %q", got)
+	}
+}
+
+func TestToolDiff(t *testing.T) {
+	before := NewAgent("a", "").WithTools("Read", "grep", "mcp__x").WithInstructions("v1")
+	after := NewAgent("a", "").WithTools("read", "Bash", "Write", "mcp__y").WithInstructions("v2")
+
+	added, removed := ToolDiff(before, after)
+	if !reflect.DeepEqual(added, []Tool{ToolWrite, ToolBash}) {
+		t.Errorf("added = %v, want [Write Bash]", added)
+	}
+	if !reflect.DeepEqual(removed, []Tool{ToolGrep}) {
+		t.Errorf("removed = %v, want [Grep]", removed)
+	}
+
+	added, removed = ToolDiff(nil, before)
+	if len(added) != 2 || removed != nil {
+		t.Errorf("ToolDiff(nil, before) = %v, %v", added, removed)
+	}
+}
@@ -0,0 +1,123 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Permission is a cloud permission a deployment target needs.
+type Permission struct {
+	// Action is the provider's permission or role name
+	// (e.g., "bedrock:InvokeModel").
+	Action string `json:"action"`
+
+	// Resource is the resource the action applies to.
+	Resource string `json:"resource"`
+
+	// Reason explains why the target needs the permission.
+	Reason string `json:"reason,omitempty"`
+}
+
+// TargetPermissions lists the cloud permissions of one deployment target.
+// Targets that run locally have no permissions.
+type TargetPermissions struct {
+	Target      string       `json:"target"`
+	Platform    Platform     `json:"platform"`
+	Permissions []Permission `json:"permissions"`
+}
+
+// PermissionReport summarizes the cloud permissions a deployment needs,
+// target by target, for security review.
+type PermissionReport struct {
+	Team    string              `json:"team"`
+	Targets []TargetPermissions `json:"targets"`
+}
+
+// PermissionsSummary derives the cloud permissions each target of the
+// deployment needs from its platform configuration and from agents, the
+// resolved team agents. AWS AgentCore targets need bedrock:InvokeModel for
+// the configured FoundationModel and for each agent's model on the platform
+// (see Agent.ModelFor); Kubernetes targets with an ImageRegistry need to pull
+// images from it, using the registry permission of the managed platform.
+// Targets without a platform configuration use the platform default. Targets
+// keep declaration order. It returns an error for a target on an unknown
+// platform.
+func (d *Deployment) PermissionsSummary(agents []*Agent) (*PermissionReport, error) {
+	report := &PermissionReport{Team: d.Team, Targets: make([]TargetPermissions, 0, len(d.Targets))}
+	for _, t := range d.Targets {
+		if !t.Platform.Valid() {
+			return nil, fmt.Errorf("permissions: target %s: unknown platform %q", t.Name, t.Platform)
+		}
+		perms := TargetPermissions{Target: t.Name, Platform: t.Platform, Permissions: []Permission{}}
+		switch t.Platform {
+		case PlatformAWSAgentCore:
+			perms.Permissions = agentCorePermissions(t, agents)
+		case PlatformKubernetes, PlatformAWSEKS, PlatformAzureAKS, PlatformGCPGKE:
+			if p, ok := registryPullPermission(t); ok {
+				perms.Permissions = append(perms.Permissions, p)
+			}
+		}
+		report.Targets = append(report.Targets, perms)
+	}
+	return report, nil
+}
+
+// agentCorePermissions returns the Bedrock model invoke permissions of an
+// AWS AgentCore target, sorted by model.
+func agentCorePermissions(t Target, agents []*Agent) []Permission {
+	cfg := t.AWSAgentCore
+	if cfg == nil {
+		cfg = t.Platform.DefaultConfig().(*AWSAgentCoreConfig)
+	}
+	region := cfg.Region
+	if region == "" {
+		region = "*"
+	}
+
+	models := make(map[string]bool)
+	if cfg.FoundationModel != "" {
+		models[cfg.FoundationModel] = true
+	}
+	for _, a := range agents {
+		if a != nil {
+			models[MapModelToBedrock(a.ModelFor(t.Platform))] = true
+		}
+	}
+	ids := make([]string, 0, len(models))
+	for id := range models {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	perms := make([]Permission, 0, len(ids))
+	for _, id := range ids {
+		perms = append(perms, Permission{
+			Action:   "bedrock:InvokeModel",
+			Resource: fmt.Sprintf("arn:aws:bedrock:%s::foundation-model/%s", region, id),
+			Reason:   "invoke the agent model",
+		})
+	}
+	return perms
+}
+
+// registryPullPermission returns the permission a Kubernetes target needs to
+// pull agent images from its ImageRegistry, if one is set.
+func registryPullPermission(t Target) (Permission, bool) {
+	if t.Kubernetes == nil || t.Kubernetes.ImageRegistry == "" {
+		return Permission{}, false
+	}
+	registry := strings.TrimRight(t.Kubernetes.ImageRegistry, "/")
+	p := Permission{Resource: registry, Reason: "pull agent images"}
+	switch t.Platform {
+	case PlatformAWSEKS:
+		p.Action = "ecr:BatchGetImage"
+	case PlatformGCPGKE:
+		p.Action = "artifactregistry.repositories.downloadArtifacts"
+	case PlatformAzureAKS:
+		p.Action = "AcrPull"
+	default:
+		p.Action = "registry:pull"
+	}
+	return p, true
+}
@@ -0,0 +1,44 @@
+package multiagentspec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDeploymentPermissionsSummary(t *testing.T) {
+	d := NewDeployment("stats").
+		AddTarget(Target{Name: "local", Platform: PlatformClaudeCode}).
+		AddTarget(Target{Name: "agentcore", Platform: PlatformAWSAgentCore, AWSAgentCore: &AWSAgentCoreConfig{Region: "eu-west-1"}}).
+		AddTarget(Target{Name: "eks", Platform: PlatformAWSEKS, Kubernetes: &KubernetesConfig{ImageRegistry: "123.dkr.ecr.eu-west-1.amazonaws.com/agents/"}}).
+		AddTarget(Target{Name: "gke", Platform: PlatformGCPGKE})
+	lead := NewAgent("lead", "")
+	lead.ModelByPlatform = map[Platform]Model{PlatformAWSAgentCore: ModelOpus}
+	agents := []*Agent{lead, NewAgent("worker", "")}
+
+	report, err := d.PermissionsSummary(agents)
+	if err != nil {
+		t.Fatalf("PermissionsSummary failed: %v", err)
+	}
+	want := &PermissionReport{
+		Team: "stats",
+		Targets: []TargetPermissions{
+			{Target: "local", Platform: PlatformClaudeCode, Permissions: []Permission{}},
+			{Target: "agentcore", Platform: PlatformAWSAgentCore, Permissions: []Permission{
+				{Action: "bedrock:InvokeModel", Resource: "arn:aws:bedrock:eu-west-1::foundation-model/" + BedrockModels[ModelSonnet], Reason: "invoke the agent model"},
+				{Action: "bedrock:InvokeModel", Resource: "arn:aws:bedrock:eu-west-1::foundation-model/" + BedrockModels[ModelOpus], Reason: "invoke the agent model"},
+			}},
+			{Target: "eks", Platform: PlatformAWSEKS, Permissions: []Permission{
+				{Action: "ecr:BatchGetImage", Resource: "123.dkr.ecr.eu-west-1.amazonaws.com/agents", Reason: "pull agent images"},
+			}},
+			{Target: "gke", Platform: PlatformGCPGKE, Permissions: []Permission{}},
+		},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("PermissionsSummary() =\n%+v\nwant\n%+v", report, want)
+	}
+
+	d.AddTarget(Target{Name: "mystery", Platform: "mainframe"})
+	if _, err := d.PermissionsSummary(agents); err == nil {
+		t.Error("PermissionsSummary should fail for an unknown platform")
+	}
+}
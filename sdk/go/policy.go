@@ -0,0 +1,206 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Bundle groups a team with its resolved agent definitions and its
+// deployment, so that organization policies can be checked across all three.
+type Bundle struct {
+	// Team is the team definition.
+	Team *Team `json:"team,omitempty"`
+
+	// Agents are the resolved definitions of the team's agents.
+	Agents []*Agent `json:"agents,omitempty"`
+
+	// Deployment is the team's deployment definition.
+	Deployment *Deployment `json:"deployment,omitempty"`
+}
+
+// PolicyViolation is a finding that a bundle breaks an organization policy.
+type PolicyViolation struct {
+	// Policy names the policy that was violated.
+	Policy string `json:"policy"`
+
+	// Path locates the violation (e.g., "agents[researcher].description").
+	Path string `json:"path,omitempty"`
+
+	// Message describes the violation.
+	Message string `json:"message"`
+}
+
+// String returns the violation formatted as "policy: path: message".
+func (v PolicyViolation) String() string {
+	if v.Path == "" {
+		return v.Policy + ": " + v.Message
+	}
+	return v.Policy + ": " + v.Path + ": " + v.Message
+}
+
+// Policy is an organization rule checked against a whole bundle, beyond what
+// schema validation enforces.
+type Policy interface {
+	Check(b *Bundle) []PolicyViolation
+}
+
+// PolicyFunc adapts an ordinary function to the Policy interface.
+type PolicyFunc func(b *Bundle) []PolicyViolation
+
+// Check calls f(b).
+func (f PolicyFunc) Check(b *Bundle) []PolicyViolation {
+	return f(b)
+}
+
+// CheckPolicies runs each policy against the bundle and returns every
+// violation, in policy order.
+func (b *Bundle) CheckPolicies(policies ...Policy) []PolicyViolation {
+	var violations []PolicyViolation
+	for _, p := range policies {
+		violations = append(violations, p.Check(b)...)
+	}
+	return violations
+}
+
+// Names of the built-in policies.
+const (
+	policyRequireAgentDescriptions = "require-agent-descriptions"
+	policyRequireTargetPlatform    = "require-target-platform"
+	policyForbidToolOnPriority     = "forbid-tool-on-priority"
+)
+
+// policies holds the registered policies, starting with the built-in ones
+// that apply to every team: every agent has a description, and no agent uses
+// Bash on p1 targets. RequireTargetPlatform depends on the kind of team, so it
+// is not registered by default.
+var (
+	policiesMu sync.RWMutex
+	policies   = map[string]Policy{
+		policyRequireAgentDescriptions: RequireAgentDescriptions(),
+		policyForbidToolOnPriority:     ForbidToolOnPriority(ToolBash, PriorityP1),
+	}
+)
+
+// RegisterPolicy registers p under name, replacing any policy already
+// registered with that name, including a built-in one. The built-in policies
+// are registered as "require-agent-descriptions" and
+// "forbid-tool-on-priority". It panics if p is nil.
+func RegisterPolicy(name string, p Policy) {
+	if p == nil {
+		panic(fmt.Sprintf("multiagentspec: RegisterPolicy: nil policy %s", name))
+	}
+	policiesMu.Lock()
+	defer policiesMu.Unlock()
+	policies[name] = p
+}
+
+// GetPolicy returns the policy registered under name.
+func GetPolicy(name string) (Policy, bool) {
+	policiesMu.RLock()
+	defer policiesMu.RUnlock()
+	p, ok := policies[name]
+	return p, ok
+}
+
+// RegisteredPolicies returns every registered policy, sorted by name, for
+// passing to CheckPolicies.
+func RegisteredPolicies() []Policy {
+	policiesMu.RLock()
+	defer policiesMu.RUnlock()
+	names := make([]string, 0, len(policies))
+	for name := range policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	list := make([]Policy, len(names))
+	for i, name := range names {
+		list[i] = policies[name]
+	}
+	return list
+}
+
+// RequireAgentDescriptions returns a policy that every agent in the bundle
+// has a non-empty Description. Nil agents are skipped.
+func RequireAgentDescriptions() Policy {
+	return PolicyFunc(func(b *Bundle) []PolicyViolation {
+		var violations []PolicyViolation
+		for _, a := range b.Agents {
+			if a == nil || strings.TrimSpace(a.Description) != "" {
+				continue
+			}
+			violations = append(violations, PolicyViolation{
+				Policy:  policyRequireAgentDescriptions,
+				Path:    fmt.Sprintf("agents[%s].description", a.QualifiedName()),
+				Message: "agent has no description",
+			})
+		}
+		return violations
+	})
+}
+
+// RequireTargetPlatform returns a policy that the bundle's deployment has at
+// least one target on one of platforms (e.g., the Kubernetes platforms for
+// production teams). A bundle without a deployment violates it.
+func RequireTargetPlatform(platforms ...Platform) Policy {
+	return PolicyFunc(func(b *Bundle) []PolicyViolation {
+		if b.Deployment != nil {
+			for _, t := range b.Deployment.Targets {
+				for _, p := range platforms {
+					if t.Platform == p {
+						return nil
+					}
+				}
+			}
+		}
+		names := make([]string, len(platforms))
+		for i, p := range platforms {
+			names[i] = string(p)
+		}
+		return []PolicyViolation{{
+			Policy:  policyRequireTargetPlatform,
+			Path:    "deployment.targets",
+			Message: fmt.Sprintf("no target on %s", strings.Join(names, ", ")),
+		}}
+	})
+}
+
+// ForbidToolOnPriority returns a policy that no agent in the bundle uses tool
+// when the deployment has a target of the given priority (e.g., no Bash on
+// p1 targets). Tool names are compared canonically, and nil agents are
+// skipped.
+func ForbidToolOnPriority(tool Tool, priority Priority) Policy {
+	return PolicyFunc(func(b *Bundle) []PolicyViolation {
+		if b.Deployment == nil {
+			return nil
+		}
+		var targets []string
+		for _, t := range b.Deployment.Targets {
+			if t.Priority == priority {
+				targets = append(targets, t.Name)
+			}
+		}
+		if len(targets) == 0 {
+			return nil
+		}
+		var violations []PolicyViolation
+		for _, a := range b.Agents {
+			if a == nil {
+				continue
+			}
+			for i, name := range a.Tools {
+				if canonical, ok := CanonicalTool(name); !ok || canonical != tool {
+					continue
+				}
+				violations = append(violations, PolicyViolation{
+					Policy: policyForbidToolOnPriority,
+					Path:   fmt.Sprintf("agents[%s].tools[%d]", a.QualifiedName(), i),
+					Message: fmt.Sprintf("%s is not allowed on %s targets (%s)",
+						tool, priority, strings.Join(targets, ", ")),
+				})
+			}
+		}
+		return violations
+	})
+}
@@ -0,0 +1,93 @@
+package multiagentspec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBundleCheckPolicies(t *testing.T) {
+	lead := NewAgent("lead", "Coordinates the team").WithTools("Task", "bash")
+	worker := NewAgent("worker", "")
+	b := &Bundle{
+		Team:   NewTeam("t", "1.0.0").WithAgents("lead", "worker"),
+		Agents: []*Agent{lead, worker},
+		Deployment: NewDeployment("t").
+			AddTarget(Target{Name: "local", Platform: PlatformClaudeCode, Priority: PriorityP2}).
+			AddTarget(Target{Name: "prod", Platform: PlatformAWSEKS, Priority: PriorityP1}),
+	}
+
+	var got []string
+	for _, v := range b.CheckPolicies(
+		RequireAgentDescriptions(),
+		RequireTargetPlatform(PlatformKubernetes, PlatformAWSEKS),
+		RequireTargetPlatform(PlatformCrewAI),
+		ForbidToolOnPriority(ToolBash, PriorityP1),
+		ForbidToolOnPriority(ToolBash, PriorityP3),
+	) {
+		got = append(got, v.String())
+	}
+	want := []string{
+		"require-agent-descriptions: agents[worker].description: agent has no description",
+		"require-target-platform: deployment.targets: no target on crewai",
+		"forbid-tool-on-priority: agents[lead].tools[1]: Bash is not allowed on p1 targets (prod)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckPolicies() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestRegisterPolicy(t *testing.T) {
+	defer func() {
+		policiesMu.Lock()
+		delete(policies, "acme-named")
+		policiesMu.Unlock()
+	}()
+
+	RegisterPolicy("acme-named", PolicyFunc(func(b *Bundle) []PolicyViolation {
+		if b.Team.Name == "unnamed" {
+			return []PolicyViolation{{Policy: "acme-named", Path: "team.name", Message: "team needs a real name"}}
+		}
+		return nil
+	}))
+	if _, ok := GetPolicy("acme-named"); !ok {
+		t.Fatal("GetPolicy did not return the registered policy")
+	}
+
+	b := &Bundle{
+		Team:       NewTeam("unnamed", "1.0.0"),
+		Agents:     []*Agent{nil, NewAgent("a", "does a")},
+		Deployment: NewDeployment("unnamed").AddTarget(Target{Name: "k8s", Platform: PlatformKubernetes}),
+	}
+	got := b.CheckPolicies(RegisteredPolicies()...)
+	if len(got) != 1 || got[0].Path != "team.name" {
+		t.Errorf("CheckPolicies(RegisteredPolicies()...) = %v", got)
+	}
+}
+
+func TestBuiltinPoliciesRegistered(t *testing.T) {
+	for _, name := range []string{"require-agent-descriptions", "forbid-tool-on-priority"} {
+		if _, ok := GetPolicy(name); !ok {
+			t.Errorf("built-in policy %s is not registered", name)
+		}
+	}
+	if _, ok := GetPolicy("require-target-platform"); ok {
+		t.Error("require-target-platform should not be registered by default")
+	}
+
+	bash := NewAgent("a", "").WithTools("Bash")
+	b := &Bundle{
+		Agents:     []*Agent{nil, bash},
+		Deployment: NewDeployment("t").AddTarget(Target{Name: "prod", Platform: PlatformClaudeCode, Priority: PriorityP1}),
+	}
+	var got []string
+	for _, v := range b.CheckPolicies(RegisteredPolicies()...) {
+		got = append(got, v.String())
+	}
+	want := []string{
+		"forbid-tool-on-priority: agents[a].tools[0]: Bash is not allowed on p1 targets (prod)",
+		"require-agent-descriptions: agents[a].description: agent has no description",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckPolicies(RegisteredPolicies()...) =\n%v\nwant\n%v", got, want)
+	}
+}
@@ -0,0 +1,187 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// AgentRegistry indexes agent definitions by qualified name so that teams,
+// workflows, and deployments can resolve the agents they reference.
+// It is safe for concurrent use by multiple goroutines.
+type AgentRegistry struct {
+	mu     sync.RWMutex
+	agents map[string]*Agent
+	order  []string
+}
+
+// NewAgentRegistry creates an empty AgentRegistry.
+func NewAgentRegistry() *AgentRegistry {
+	return &AgentRegistry{
+		agents: make(map[string]*Agent),
+	}
+}
+
+// Register adds an agent to the registry under its qualified name.
+// It returns an error if the agent has no name or the name is already taken.
+func (r *AgentRegistry) Register(agent *Agent) error {
+	if agent == nil || agent.Name == "" {
+		return fmt.Errorf("register agent: name is required")
+	}
+
+	key := agent.QualifiedName()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.agents[key]; exists {
+		return fmt.Errorf("register agent: %q already registered", key)
+	}
+
+	r.agents[key] = agent
+	r.order = append(r.order, key)
+	return nil
+}
+
+// Get returns the agent registered under the given qualified name.
+func (r *AgentRegistry) Get(name string) (*Agent, bool) {
+	if r == nil {
+		return nil, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	agent, ok := r.agents[name]
+	return agent, ok
+}
+
+// All returns the registered agents in registration order.
+func (r *AgentRegistry) All() []*Agent {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	agents := make([]*Agent, 0, len(r.order))
+	for _, key := range r.order {
+		agents = append(agents, r.agents[key])
+	}
+	return agents
+}
+
+// Resolve returns the agents for the given names, in the same order.
+// It returns an error listing every name that is not registered.
+func (r *AgentRegistry) Resolve(names []string) ([]*Agent, error) {
+	agents := make([]*Agent, 0, len(names))
+	var missing []string
+	for _, name := range names {
+		agent, ok := r.Get(name)
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+		agents = append(agents, agent)
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("unknown agents: %s", strings.Join(missing, ", "))
+	}
+	return agents, nil
+}
+
+// Dependents returns the qualified names of the registered agents that list
+// name directly in their Dependencies, in registration order.
+func (r *AgentRegistry) Dependents(name string) []string {
+	var dependents []string
+	for _, agent := range r.All() {
+		for _, dep := range agent.Dependencies {
+			if dependencyName(dep) == name {
+				dependents = append(dependents, agent.QualifiedName())
+				break
+			}
+		}
+	}
+	return dependents
+}
+
+// TransitiveDependents returns the qualified names of the registered agents
+// that depend on name directly or through other agents, in registration
+// order. Dependency cycles are tolerated, and name itself is never included.
+func (r *AgentRegistry) TransitiveDependents(name string) []string {
+	affected := map[string]bool{name: true}
+	queue := []string{name}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		for _, dependent := range r.Dependents(next) {
+			if !affected[dependent] {
+				affected[dependent] = true
+				queue = append(queue, dependent)
+			}
+		}
+	}
+
+	var dependents []string
+	for _, agent := range r.All() {
+		if key := agent.QualifiedName(); key != name && affected[key] {
+			dependents = append(dependents, key)
+		}
+	}
+	return dependents
+}
+
+// DeduplicateAgents collapses agents that share a qualified name. Identical
+// definitions (see Agent.Equal) are merged into one; a group whose definitions
+// differ is dropped from the result and reported as an error. The result keeps
+// the order in which each name first appears.
+func DeduplicateAgents(agents []*Agent) ([]*Agent, []error) {
+	return DeduplicateAgentsWith(agents, nil)
+}
+
+// DeduplicateAgentsWith is like DeduplicateAgents, but resolves differing
+// definitions by calling merge with the current result for the name and the
+// next conflicting definition. A nil merge treats every difference as a
+// conflict. If merge returns an error, the group is dropped and the error
+// is reported.
+func DeduplicateAgentsWith(agents []*Agent, merge func(existing, incoming *Agent) (*Agent, error)) ([]*Agent, []error) {
+	merged := make(map[string]*Agent)
+	failed := make(map[string]bool)
+	var order []string
+	var errs []error
+
+	for _, agent := range agents {
+		if agent == nil {
+			continue
+		}
+		key := agent.QualifiedName()
+		existing, seen := merged[key]
+		if !seen {
+			if !failed[key] {
+				merged[key] = agent
+				order = append(order, key)
+			}
+			continue
+		}
+		if existing.Equal(agent) {
+			continue
+		}
+
+		if merge == nil {
+			errs = append(errs, fmt.Errorf("agent %q: conflicting definitions", key))
+		} else if result, err := merge(existing, agent); err != nil {
+			errs = append(errs, fmt.Errorf("agent %q: merge: %w", key, err))
+		} else {
+			merged[key] = result
+			continue
+		}
+		delete(merged, key)
+		failed[key] = true
+	}
+
+	result := make([]*Agent, 0, len(order))
+	for _, key := range order {
+		if agent, ok := merged[key]; ok {
+			result = append(result, agent)
+		}
+	}
+	return result, errs
+}
@@ -0,0 +1,197 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newTestRegistry builds a registry from agents, failing the test on error.
+func newTestRegistry(t *testing.T, agents ...*Agent) *AgentRegistry {
+	t.Helper()
+	r := NewAgentRegistry()
+	for _, a := range agents {
+		if err := r.Register(a); err != nil {
+			t.Fatalf("Register(%q) failed: %v", a.Name, err)
+		}
+	}
+	return r
+}
+
+func TestAgentRegistryRegister(t *testing.T) {
+	r := NewAgentRegistry()
+
+	if err := r.Register(NewAgent("reviewer", "")); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := r.Register(NewAgent("reviewer", "")); err == nil {
+		t.Error("Register should fail for duplicate name")
+	}
+	if err := r.Register(&Agent{}); err == nil {
+		t.Error("Register should fail for empty name")
+	}
+	if err := r.Register(NewAgent("reviewer", "").WithNamespace("shared")); err != nil {
+		t.Errorf("Register should accept same name in another namespace: %v", err)
+	}
+
+	if _, ok := r.Get("shared/reviewer"); !ok {
+		t.Error("Get(shared/reviewer) not found")
+	}
+	if _, ok := r.Get("missing"); ok {
+		t.Error("Get(missing) should not be found")
+	}
+}
+
+func TestAgentRegistryAll(t *testing.T) {
+	r := newTestRegistry(t, NewAgent("b", ""), NewAgent("a", ""), NewAgent("c", ""))
+
+	all := r.All()
+	if len(all) != 3 {
+		t.Fatalf("len(All()) = %d, want 3", len(all))
+	}
+	for i, want := range []string{"b", "a", "c"} {
+		if all[i].Name != want {
+			t.Errorf("All()[%d].Name = %q, want %q", i, all[i].Name, want)
+		}
+	}
+}
+
+func TestAgentRegistryResolve(t *testing.T) {
+	r := newTestRegistry(t, NewAgent("a", ""), NewAgent("b", ""))
+
+	agents, err := r.Resolve([]string{"b", "a"})
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if agents[0].Name != "b" || agents[1].Name != "a" {
+		t.Errorf("Resolve order = [%s %s], want [b a]", agents[0].Name, agents[1].Name)
+	}
+
+	_, err = r.Resolve([]string{"a", "x", "y"})
+	if err == nil {
+		t.Fatal("Resolve should fail for unknown agents")
+	}
+	if !strings.Contains(err.Error(), "x, y") {
+		t.Errorf("error = %q, want it to list missing agents", err)
+	}
+}
+
+func TestAgentRegistryNil(t *testing.T) {
+	var r *AgentRegistry
+	if _, ok := r.Get("a"); ok {
+		t.Error("nil registry Get should not find agents")
+	}
+	if r.All() != nil {
+		t.Error("nil registry All should return nil")
+	}
+}
+
+func TestAgentRegistryConcurrentAccess(t *testing.T) {
+	r := NewAgentRegistry()
+
+	const goroutines = 16
+	const perGoroutine = 50
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				name := fmt.Sprintf("agent-%d-%d", g, i)
+				if err := r.Register(NewAgent(name, "")); err != nil {
+					t.Errorf("Register(%q) failed: %v", name, err)
+				}
+				if _, ok := r.Get(name); !ok {
+					t.Errorf("Get(%q) not found after Register", name)
+				}
+				_ = r.All()
+				if _, err := r.Resolve([]string{name}); err != nil {
+					t.Errorf("Resolve(%q) failed: %v", name, err)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if got := len(r.All()); got != goroutines*perGoroutine {
+		t.Errorf("len(All()) = %d, want %d", got, goroutines*perGoroutine)
+	}
+}
+
+func TestDeduplicateAgents(t *testing.T) {
+	agents := []*Agent{
+		NewAgent("research", "Find sources"),
+		NewAgent("synthesis", "v1"),
+		{Name: "research", Description: "Find sources"}, // identical: unset model is sonnet
+		NewAgent("synthesis", "v2"),
+		NewAgent("synthesis", "v3"),
+		NewAgent("research", "").WithNamespace("shared"),
+	}
+
+	result, errs := DeduplicateAgents(agents)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), `agent "synthesis": conflicting definitions`) {
+		t.Errorf("errs = %v, want one synthesis conflict", errs)
+	}
+	var names []string
+	for _, a := range result {
+		names = append(names, a.QualifiedName())
+	}
+	if got := strings.Join(names, ","); got != "research,shared/research" {
+		t.Errorf("result = %s, want research,shared/research", got)
+	}
+}
+
+func TestDeduplicateAgentsWith(t *testing.T) {
+	agents := []*Agent{
+		NewAgent("a", "").WithTools("Read"),
+		NewAgent("a", "").WithTools("Write"),
+		NewAgent("b", "x"),
+		NewAgent("b", "y"),
+	}
+
+	union := func(existing, incoming *Agent) (*Agent, error) {
+		if existing.Description != incoming.Description {
+			return nil, fmt.Errorf("descriptions differ")
+		}
+		merged := *existing
+		merged.Tools = append(append([]string(nil), existing.Tools...), incoming.Tools...)
+		return &merged, nil
+	}
+
+	result, errs := DeduplicateAgentsWith(agents, union)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "merge: descriptions differ") {
+		t.Errorf("errs = %v, want one merge error", errs)
+	}
+	if len(result) != 1 || strings.Join(result[0].Tools, ",") != "Read,Write" {
+		t.Errorf("result = %+v, want merged agent a", result)
+	}
+}
+
+func TestAgentRegistryDependents(t *testing.T) {
+	base := NewAgent("base", "")
+	mid := NewAgent("mid", "")
+	mid.Dependencies = []string{"base@>=1.0.0"}
+	top := NewAgent("top", "")
+	top.Dependencies = []string{"mid", "base"}
+	leaf := NewAgent("leaf", "")
+	leaf.Dependencies = []string{"top"}
+	loop := NewAgent("loop", "")
+	loop.Dependencies = []string{"leaf", "loop"}
+	other := NewAgent("other", "")
+	r := newTestRegistry(t, base, mid, top, leaf, loop, other)
+
+	if got := strings.Join(r.Dependents("base"), ","); got != "mid,top" {
+		t.Errorf("Dependents(base) = %s, want mid,top", got)
+	}
+	if got := strings.Join(r.TransitiveDependents("base"), ","); got != "mid,top,leaf,loop" {
+		t.Errorf("TransitiveDependents(base) = %s, want mid,top,leaf,loop", got)
+	}
+	if got := strings.Join(r.TransitiveDependents("loop"), ","); got != "" {
+		t.Errorf("TransitiveDependents(loop) = %s, want none", got)
+	}
+	if got := r.Dependents("other"); got != nil {
+		t.Errorf("Dependents(other) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,140 @@
+package multiagentspec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// maxRemoteSpecSize caps the size of definitions fetched by the URL loaders.
+const maxRemoteSpecSize = 10 << 20
+
+// LoadAgentFromURL fetches and validates an Agent definition over HTTP.
+// The response may be JSON (application/json) or markdown with YAML
+// frontmatter (text/markdown). A nil client uses http.DefaultClient.
+func LoadAgentFromURL(ctx context.Context, url string, client *http.Client) (*Agent, error) {
+	data, mediaType, err := fetchSpec(ctx, url, client, "application/json", "text/markdown")
+	if err != nil {
+		return nil, err
+	}
+
+	var agent *Agent
+	if mediaType == "text/markdown" {
+		agent, err = ParseAgentMarkdown(data)
+		if err != nil {
+			return nil, fmt.Errorf("load %s: %w", url, err)
+		}
+	} else {
+		agent = &Agent{}
+		if err := json.Unmarshal(data, agent); err != nil {
+			return nil, fmt.Errorf("load %s: parse json: %w", url, err)
+		}
+	}
+
+	if err := agent.Validate(); err != nil {
+		return nil, fmt.Errorf("load %s: validate agent: %w", url, err)
+	}
+	return agent, nil
+}
+
+// LoadTeamFromURL fetches and validates a JSON Team definition over HTTP.
+// A nil client uses http.DefaultClient.
+func LoadTeamFromURL(ctx context.Context, url string, client *http.Client) (*Team, error) {
+	data, _, err := fetchSpec(ctx, url, client, "application/json")
+	if err != nil {
+		return nil, err
+	}
+
+	var team Team
+	if err := json.Unmarshal(data, &team); err != nil {
+		return nil, fmt.Errorf("load %s: parse json: %w", url, err)
+	}
+	if team.Version != "" {
+		version, err := NormalizeVersion(team.Version)
+		if err != nil {
+			return nil, fmt.Errorf("load %s: normalize version: %w", url, err)
+		}
+		team.Version = version
+	}
+	if err := team.Validate(); err != nil {
+		return nil, fmt.Errorf("load %s: validate team: %w", url, err)
+	}
+	return &team, nil
+}
+
+// LoadDeploymentFromURL fetches and validates a JSON Deployment definition
+// over HTTP. A nil client uses http.DefaultClient.
+func LoadDeploymentFromURL(ctx context.Context, url string, client *http.Client) (*Deployment, error) {
+	data, _, err := fetchSpec(ctx, url, client, "application/json")
+	if err != nil {
+		return nil, err
+	}
+
+	var deployment Deployment
+	if err := json.Unmarshal(data, &deployment); err != nil {
+		return nil, fmt.Errorf("load %s: parse json: %w", url, err)
+	}
+	if err := deployment.Validate(); err != nil {
+		return nil, fmt.Errorf("load %s: validate deployment: %w", url, err)
+	}
+	return &deployment, nil
+}
+
+// fetchSpec GETs url and returns the body along with its media type, which
+// must be one of accepted. Structured "+json" media types are reported as
+// application/json.
+func fetchSpec(ctx context.Context, url string, client *http.Client, accepted ...string) ([]byte, string, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("fetch %s: %w", url, err)
+	}
+	req.Header.Set("Accept", strings.Join(accepted, ", "))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, "", fmt.Errorf("fetch %s: unexpected status %s", url, resp.Status)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, "", fmt.Errorf("fetch %s: invalid content type %q: %w", url, resp.Header.Get("Content-Type"), err)
+	}
+	if strings.HasPrefix(mediaType, "application/") && strings.HasSuffix(mediaType, "+json") {
+		mediaType = "application/json"
+	}
+	if !containsString(accepted, mediaType) {
+		return nil, "", fmt.Errorf("fetch %s: unsupported content type %q (want %s)", url, mediaType, strings.Join(accepted, " or "))
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteSpecSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("fetch %s: read body: %w", url, err)
+	}
+	if len(data) > maxRemoteSpecSize {
+		return nil, "", fmt.Errorf("fetch %s: body exceeds %d bytes", url, maxRemoteSpecSize)
+	}
+	return data, mediaType, nil
+}
+
+// containsString reports whether list contains s.
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
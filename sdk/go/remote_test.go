@@ -0,0 +1,121 @@
+package multiagentspec
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// specServer serves body with the given content type at every path.
+func specServer(t *testing.T, contentType, body string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestLoadAgentFromURL(t *testing.T) {
+	srv := specServer(t, "application/json; charset=utf-8", `{"name":"reviewer","model":"opus"}`)
+
+	agent, err := LoadAgentFromURL(context.Background(), srv.URL+"/agents/reviewer", nil)
+	if err != nil {
+		t.Fatalf("LoadAgentFromURL failed: %v", err)
+	}
+	if agent.Name != "reviewer" || agent.Model != ModelOpus {
+		t.Errorf("agent = %+v", agent)
+	}
+}
+
+func TestLoadAgentFromURLMarkdown(t *testing.T) {
+	srv := specServer(t, "text/markdown", "---\nname: reviewer\n---\n\nReview carefully.\n")
+
+	agent, err := LoadAgentFromURL(context.Background(), srv.URL, srv.Client())
+	if err != nil {
+		t.Fatalf("LoadAgentFromURL failed: %v", err)
+	}
+	if agent.Instructions != "Review carefully." {
+		t.Errorf("Instructions = %q", agent.Instructions)
+	}
+}
+
+func TestLoadAgentFromURLErrors(t *testing.T) {
+	ctx := context.Background()
+
+	html := specServer(t, "text/html", "<html></html>")
+	if _, err := LoadAgentFromURL(ctx, html.URL, nil); err == nil || !strings.Contains(err.Error(), `unsupported content type "text/html"`) {
+		t.Errorf("error = %v, want content type error", err)
+	}
+
+	if _, err := LoadAgentFromURL(ctx, html.URL+"/missing", nil); err == nil || !strings.Contains(err.Error(), "unexpected status 404") {
+		t.Errorf("error = %v, want status error", err)
+	}
+
+	invalid := specServer(t, "application/json", `{"description":"no name"}`)
+	if _, err := LoadAgentFromURL(ctx, invalid.URL, nil); err == nil || !strings.Contains(err.Error(), "name: is required") {
+		t.Errorf("error = %v, want validation error", err)
+	}
+}
+
+func TestLoadAgentFromURLContextCanceled(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	defer srv.Close()
+	defer close(block)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := LoadAgentFromURL(ctx, srv.URL, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestLoadTeamFromURL(t *testing.T) {
+	srv := specServer(t, "application/vnd.multiagent.team+json",
+		`{"name":"t","version":"1.0.0","agents":["lead"],"orchestrator":"lead"}`)
+
+	team, err := LoadTeamFromURL(context.Background(), srv.URL, nil)
+	if err != nil {
+		t.Fatalf("LoadTeamFromURL failed: %v", err)
+	}
+	if team.Orchestrator != "lead" {
+		t.Errorf("team = %+v", team)
+	}
+
+	md := specServer(t, "text/markdown", "# team")
+	if _, err := LoadTeamFromURL(context.Background(), md.URL, nil); err == nil {
+		t.Error("LoadTeamFromURL should reject markdown")
+	}
+}
+
+func TestLoadDeploymentFromURL(t *testing.T) {
+	srv := specServer(t, "application/json",
+		`{"team":"t","targets":[{"name":"local","platform":"claude-code"}]}`)
+
+	deployment, err := LoadDeploymentFromURL(context.Background(), srv.URL, nil)
+	if err != nil {
+		t.Fatalf("LoadDeploymentFromURL failed: %v", err)
+	}
+	if len(deployment.Targets) != 1 {
+		t.Errorf("deployment = %+v", deployment)
+	}
+
+	invalid := specServer(t, "application/json", `{"team":"t","targets":[{"name":"x","platform":"mainframe"}]}`)
+	if _, err := LoadDeploymentFromURL(context.Background(), invalid.URL, nil); err == nil {
+		t.Error("LoadDeploymentFromURL should reject unknown platforms")
+	}
+}
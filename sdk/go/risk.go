@@ -0,0 +1,62 @@
+package multiagentspec
+
+import "sort"
+
+// Tool risk levels used by ToolRiskLevel.
+const (
+	RiskLow    = 1
+	RiskMedium = 2
+	RiskHigh   = 3
+)
+
+// ToolRiskLevel maps canonical tools to the risk of granting them: tools that
+// execute commands or modify files are high, read-only tools are low.
+var ToolRiskLevel = map[Tool]int{
+	ToolBash:      RiskHigh,
+	ToolWrite:     RiskHigh,
+	ToolEdit:      RiskMedium,
+	ToolTask:      RiskMedium,
+	ToolWebFetch:  RiskMedium,
+	ToolWebSearch: RiskLow,
+	ToolRead:      RiskLow,
+	ToolGlob:      RiskLow,
+	ToolGrep:      RiskLow,
+}
+
+// RiskScore returns the sum of ToolRiskLevel over the agent's distinct
+// canonical Tools. Tools not in ToolRiskLevel contribute nothing.
+func (a *Agent) RiskScore() int {
+	seen := make(map[Tool]bool, len(a.Tools))
+	score := 0
+	for _, name := range a.Tools {
+		tool, ok := CanonicalTool(name)
+		if !ok || seen[tool] {
+			continue
+		}
+		seen[tool] = true
+		score += ToolRiskLevel[tool]
+	}
+	return score
+}
+
+// AgentRisk pairs an agent with its RiskScore.
+type AgentRisk struct {
+	Agent *Agent `json:"agent"`
+	Score int    `json:"score"`
+}
+
+// RankByRisk returns the agents sorted by RiskScore, highest first. Agents of
+// equal score keep their input order; nil agents are skipped.
+func RankByRisk(agents []*Agent) []AgentRisk {
+	risks := make([]AgentRisk, 0, len(agents))
+	for _, a := range agents {
+		if a == nil {
+			continue
+		}
+		risks = append(risks, AgentRisk{Agent: a, Score: a.RiskScore()})
+	}
+	sort.SliceStable(risks, func(i, j int) bool {
+		return risks[i].Score > risks[j].Score
+	})
+	return risks
+}
@@ -0,0 +1,41 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestAgentRiskScore(t *testing.T) {
+	agent := NewAgent("a", "").WithTools("Bash", "Read", "read", "mcp__db__query")
+	if got := agent.RiskScore(); got != RiskHigh+RiskLow {
+		t.Errorf("RiskScore() = %d, want %d", got, RiskHigh+RiskLow)
+	}
+	if got := NewAgent("b", "").RiskScore(); got != 0 {
+		t.Errorf("RiskScore() = %d for agent without tools", got)
+	}
+}
+
+func TestToolRiskLevelCoversCanonicalTools(t *testing.T) {
+	for _, tool := range canonicalTools {
+		if _, ok := ToolRiskLevel[tool]; !ok {
+			t.Errorf("ToolRiskLevel missing %s", tool)
+		}
+	}
+}
+
+func TestRankByRisk(t *testing.T) {
+	agents := []*Agent{
+		NewAgent("reader", "").WithTools("Read", "Grep"),
+		NewAgent("builder", "").WithTools("Bash", "Write", "Edit"),
+		nil,
+		NewAgent("searcher", "").WithTools("WebSearch", "Glob"),
+	}
+	var got []string
+	for _, r := range RankByRisk(agents) {
+		got = append(got, fmt.Sprintf("%s=%d", r.Agent.Name, r.Score))
+	}
+	if want := "builder=8 reader=2 searcher=2"; strings.Join(got, " ") != want {
+		t.Errorf("RankByRisk() = %v, want %s", got, want)
+	}
+}
@@ -0,0 +1,132 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// cycloneDXSpecVersion is the CycloneDX specification version of ToSBOM output.
+const cycloneDXSpecVersion = "1.5"
+
+type cdxBOM struct {
+	BOMFormat    string          `json:"bomFormat"`
+	SpecVersion  string          `json:"specVersion"`
+	Version      int             `json:"version"`
+	Metadata     cdxMetadata     `json:"metadata"`
+	Components   []cdxComponent  `json:"components"`
+	Dependencies []cdxDependency `json:"dependencies"`
+}
+
+type cdxMetadata struct {
+	Component cdxComponent `json:"component"`
+}
+
+type cdxComponent struct {
+	Type        string `json:"type"`
+	BOMRef      string `json:"bom-ref"`
+	Name        string `json:"name"`
+	Version     string `json:"version,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type cdxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+// ToSBOM renders a CycloneDX 1.5 JSON software bill of materials for the team.
+// The team is the BOM's subject; each team agent is an "application"
+// component with its Version, each effective model a "machine-learning-model"
+// component, and each binary in the agents' Requires an "application"
+// component. The dependency graph links the team to its agents and each agent
+// to its model, binaries, and the team agents it depends on.
+func (t *Team) ToSBOM(agents []*Agent) ([]byte, error) {
+	members, err := teamMembers(t, agents)
+	if err != nil {
+		return nil, fmt.Errorf("sbom: %w", err)
+	}
+
+	teamRef := "team:" + t.Name
+	bom := cdxBOM{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: cycloneDXSpecVersion,
+		Version:     1,
+		Metadata: cdxMetadata{Component: cdxComponent{
+			Type:        "application",
+			BOMRef:      teamRef,
+			Name:        t.Name,
+			Version:     t.Version,
+			Description: t.Description,
+		}},
+		Components: []cdxComponent{},
+	}
+
+	agentRefs := make(map[string]string, len(members)*2)
+	for _, a := range members {
+		ref := "agent:" + a.QualifiedName()
+		agentRefs[a.Name] = ref
+		agentRefs[a.QualifiedName()] = ref
+	}
+
+	models := make(map[Model]bool)
+	binaries := make(map[string]bool)
+	teamDeps := cdxDependency{Ref: teamRef}
+	var agentDeps []cdxDependency
+	for _, a := range members {
+		ref := agentRefs[a.QualifiedName()]
+		bom.Components = append(bom.Components, cdxComponent{
+			Type:        "application",
+			BOMRef:      ref,
+			Name:        a.QualifiedName(),
+			Version:     a.Version,
+			Description: a.Description,
+		})
+		teamDeps.DependsOn = append(teamDeps.DependsOn, ref)
+
+		model := a.effectiveModel()
+		models[model] = true
+		dep := cdxDependency{Ref: ref, DependsOn: []string{"model:" + string(model)}}
+		for _, bin := range a.Requires {
+			if bin == "" {
+				continue
+			}
+			binaries[bin] = true
+			dep.DependsOn = append(dep.DependsOn, "bin:"+bin)
+		}
+		for _, d := range a.Dependencies {
+			if r, ok := agentRefs[dependencyName(d)]; ok && !containsString(dep.DependsOn, r) {
+				dep.DependsOn = append(dep.DependsOn, r)
+			}
+		}
+		agentDeps = append(agentDeps, dep)
+	}
+
+	for _, m := range CollectModels(members) {
+		bom.Components = append(bom.Components, cdxComponent{
+			Type:   "machine-learning-model",
+			BOMRef: "model:" + string(m),
+			Name:   string(m),
+		})
+	}
+	bins := make([]string, 0, len(binaries))
+	for bin := range binaries {
+		bins = append(bins, bin)
+	}
+	sort.Strings(bins)
+	for _, bin := range bins {
+		bom.Components = append(bom.Components, cdxComponent{
+			Type:   "application",
+			BOMRef: "bin:" + bin,
+			Name:   bin,
+		})
+	}
+
+	bom.Dependencies = append([]cdxDependency{teamDeps}, agentDeps...)
+
+	data, err := json.MarshalIndent(bom, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("sbom: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,62 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestTeamToSBOM(t *testing.T) {
+	team := NewTeam("stats", "1.0.0").WithAgents("research", "synthesis")
+	research := NewAgent("research", "").WithModel(ModelHaiku)
+	research.Version = "1.2.0"
+	research.Requires = []string{"git"}
+	synthesis := NewAgent("synthesis", "")
+	synthesis.Requires = []string{"go", "git"}
+	synthesis.Dependencies = []string{"research@>=1.0.0", "external"}
+
+	data, err := team.ToSBOM([]*Agent{research, synthesis})
+	if err != nil {
+		t.Fatalf("ToSBOM failed: %v", err)
+	}
+
+	var bom cdxBOM
+	if err := json.Unmarshal(data, &bom); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v\n%s", err, data)
+	}
+	if bom.BOMFormat != "CycloneDX" || bom.SpecVersion != "1.5" || bom.Metadata.Component.BOMRef != "team:stats" {
+		t.Errorf("header = %+v", bom)
+	}
+
+	var refs []string
+	for _, c := range bom.Components {
+		refs = append(refs, c.Type+"="+c.BOMRef)
+	}
+	want := "application=agent:research,application=agent:synthesis," +
+		"machine-learning-model=model:haiku,machine-learning-model=model:sonnet," +
+		"application=bin:git,application=bin:go"
+	if got := strings.Join(refs, ","); got != want {
+		t.Errorf("components = %s\nwant %s", got, want)
+	}
+	if bom.Components[0].Version != "1.2.0" {
+		t.Errorf("research version = %q", bom.Components[0].Version)
+	}
+
+	deps := make(map[string]string)
+	for _, d := range bom.Dependencies {
+		deps[d.Ref] = strings.Join(d.DependsOn, ",")
+	}
+	if deps["team:stats"] != "agent:research,agent:synthesis" {
+		t.Errorf("team dependencies = %s", deps["team:stats"])
+	}
+	if deps["agent:synthesis"] != "model:sonnet,bin:go,bin:git,agent:research" {
+		t.Errorf("synthesis dependencies = %s", deps["agent:synthesis"])
+	}
+}
+
+func TestTeamToSBOMMissingAgent(t *testing.T) {
+	team := NewTeam("t", "1.0.0").WithAgents("ghost")
+	if _, err := team.ToSBOM(nil); err == nil {
+		t.Error("ToSBOM should fail for agents missing from definitions")
+	}
+}
@@ -0,0 +1,438 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// schemaKeywordKind describes the expected JSON shape of a schema keyword.
+type schemaKeywordKind int
+
+const (
+	kindString schemaKeywordKind = iota
+	kindNumber
+	kindNonNegativeInt
+	kindBool
+	kindArray
+	kindStringArray
+	kindSchema
+	kindSchemaArray
+	kindSchemaMap
+	kindSchemaOrSchemaArray
+	kindType
+	kindPattern
+)
+
+// schemaKeywords lists the JSON Schema keywords whose values are checked.
+// Unknown keywords are permitted, as required by the JSON Schema specification.
+var schemaKeywords = map[string]schemaKeywordKind{
+	"$schema":               kindString,
+	"$id":                   kindString,
+	"$ref":                  kindString,
+	"$comment":              kindString,
+	"title":                 kindString,
+	"description":           kindString,
+	"format":                kindString,
+	"type":                  kindType,
+	"enum":                  kindArray,
+	"required":              kindStringArray,
+	"properties":            kindSchemaMap,
+	"patternProperties":     kindSchemaMap,
+	"$defs":                 kindSchemaMap,
+	"definitions":           kindSchemaMap,
+	"additionalProperties":  kindSchema,
+	"unevaluatedProperties": kindSchema,
+	"propertyNames":         kindSchema,
+	"items":                 kindSchemaOrSchemaArray,
+	"prefixItems":           kindSchemaArray,
+	"additionalItems":       kindSchema,
+	"unevaluatedItems":      kindSchema,
+	"contains":              kindSchema,
+	"allOf":                 kindSchemaArray,
+	"anyOf":                 kindSchemaArray,
+	"oneOf":                 kindSchemaArray,
+	"not":                   kindSchema,
+	"if":                    kindSchema,
+	"then":                  kindSchema,
+	"else":                  kindSchema,
+	"minimum":               kindNumber,
+	"maximum":               kindNumber,
+	"exclusiveMinimum":      kindNumber,
+	"exclusiveMaximum":      kindNumber,
+	"multipleOf":            kindNumber,
+	"minLength":             kindNonNegativeInt,
+	"maxLength":             kindNonNegativeInt,
+	"minItems":              kindNonNegativeInt,
+	"maxItems":              kindNonNegativeInt,
+	"minProperties":         kindNonNegativeInt,
+	"maxProperties":         kindNonNegativeInt,
+	"uniqueItems":           kindBool,
+	"readOnly":              kindBool,
+	"writeOnly":             kindBool,
+	"deprecated":            kindBool,
+	"pattern":               kindPattern,
+}
+
+// schemaTypes are the primitive type names allowed by the "type" keyword.
+var schemaTypes = map[string]bool{
+	"null": true, "boolean": true, "object": true, "array": true,
+	"number": true, "integer": true, "string": true,
+}
+
+// ValidateSchemaSyntax checks that Schema, if set, is a syntactically valid
+// JSON Schema: it must parse as JSON, and every known keyword must have a
+// value of the correct type.
+func (p *Port) ValidateSchemaSyntax() error {
+	if len(p.Schema) == 0 {
+		return nil
+	}
+	if err := validateSchemaSyntax(p.Schema); err != nil {
+		return fmt.Errorf("port %s: invalid schema: %w", p.Name, err)
+	}
+	return nil
+}
+
+// validateSchemaSyntax parses raw and checks it as a JSON Schema document.
+func validateSchemaSyntax(raw json.RawMessage) error {
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return fmt.Errorf("parse json: %w", err)
+	}
+	return checkSchema(v, "#")
+}
+
+// checkSchema validates a decoded schema value located at path.
+func checkSchema(v interface{}, path string) error {
+	switch s := v.(type) {
+	case bool:
+		return nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(s))
+		for k := range s {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			kind, known := schemaKeywords[k]
+			if !known {
+				continue
+			}
+			if err := checkKeyword(kind, s[k], path+"/"+k); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("%s: schema must be an object or boolean", path)
+	}
+}
+
+// checkKeyword validates a single keyword value against its expected kind.
+func checkKeyword(kind schemaKeywordKind, v interface{}, path string) error {
+	switch kind {
+	case kindString:
+		if _, ok := v.(string); !ok {
+			return fmt.Errorf("%s: must be a string", path)
+		}
+	case kindNumber:
+		if _, ok := v.(float64); !ok {
+			return fmt.Errorf("%s: must be a number", path)
+		}
+	case kindNonNegativeInt:
+		n, ok := v.(float64)
+		if !ok || n < 0 || n != float64(int64(n)) {
+			return fmt.Errorf("%s: must be a non-negative integer", path)
+		}
+	case kindBool:
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("%s: must be a boolean", path)
+		}
+	case kindArray:
+		if _, ok := v.([]interface{}); !ok {
+			return fmt.Errorf("%s: must be an array", path)
+		}
+	case kindStringArray:
+		items, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: must be an array of strings", path)
+		}
+		for _, item := range items {
+			if _, ok := item.(string); !ok {
+				return fmt.Errorf("%s: must be an array of strings", path)
+			}
+		}
+	case kindSchema:
+		return checkSchema(v, path)
+	case kindSchemaArray:
+		items, ok := v.([]interface{})
+		if !ok || len(items) == 0 {
+			return fmt.Errorf("%s: must be a non-empty array of schemas", path)
+		}
+		for i, item := range items {
+			if err := checkSchema(item, fmt.Sprintf("%s/%d", path, i)); err != nil {
+				return err
+			}
+		}
+	case kindSchemaMap:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: must be an object of schemas", path)
+		}
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if err := checkSchema(m[k], path+"/"+k); err != nil {
+				return err
+			}
+		}
+	case kindSchemaOrSchemaArray:
+		if _, ok := v.([]interface{}); ok {
+			return checkKeyword(kindSchemaArray, v, path)
+		}
+		return checkSchema(v, path)
+	case kindType:
+		return checkSchemaType(v, path)
+	case kindPattern:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%s: must be a string", path)
+		}
+		if _, err := regexp.Compile(s); err != nil {
+			return fmt.Errorf("%s: invalid pattern: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// checkSchemaType validates the "type" keyword, which is either a type name
+// or a non-empty array of unique type names.
+func checkSchemaType(v interface{}, path string) error {
+	switch t := v.(type) {
+	case string:
+		if !schemaTypes[t] {
+			return fmt.Errorf("%s: unknown type %q", path, t)
+		}
+	case []interface{}:
+		if len(t) == 0 {
+			return fmt.Errorf("%s: must not be empty", path)
+		}
+		seen := make(map[string]bool)
+		for _, item := range t {
+			name, ok := item.(string)
+			if !ok || !schemaTypes[name] {
+				return fmt.Errorf("%s: unknown type %v", path, item)
+			}
+			if seen[name] {
+				return fmt.Errorf("%s: duplicate type %q", path, name)
+			}
+			seen[name] = true
+		}
+	default:
+		return fmt.Errorf("%s: must be a string or array of strings", path)
+	}
+	return nil
+}
+
+// validateInstance records in errs every way a decoded JSON value violates
+// schema, with path a JSON Pointer to the value. It supports the type, enum,
+// const, properties, required, additionalProperties, items, prefixItems,
+// length, size, and numeric range keywords, pattern, and allOf, anyOf, oneOf,
+// and not. Other keywords, including $ref, are ignored.
+func validateInstance(schema, v interface{}, path string, errs *ValidationErrors) {
+	switch s := schema.(type) {
+	case bool:
+		if !s {
+			errs.addf(path, "no value is allowed")
+		}
+		return
+	case map[string]interface{}:
+		validateInstanceObject(s, v, path, errs)
+	}
+}
+
+// validateInstanceObject validates v against an object schema s.
+func validateInstanceObject(s map[string]interface{}, v interface{}, path string, errs *ValidationErrors) {
+	if t, ok := s["type"]; ok && !matchesSchemaType(t, v) {
+		errs.addf(path, "must be of type %s", formatSchemaType(t))
+		return
+	}
+	if enum, ok := s["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			if reflect.DeepEqual(e, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs.addf(path, "must be one of the enum values")
+		}
+	}
+	if c, ok := s["const"]; ok && !reflect.DeepEqual(c, v) {
+		errs.addf(path, "must equal the const value")
+	}
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		props, _ := s["properties"].(map[string]interface{})
+		if req, ok := s["required"].([]interface{}); ok {
+			for _, r := range req {
+				if name, ok := r.(string); ok {
+					if _, present := val[name]; !present {
+						errs.addf(path, "missing required property %q", name)
+					}
+				}
+			}
+		}
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			child := path + "/" + k
+			if ps, ok := props[k]; ok {
+				validateInstance(ps, val[k], child, errs)
+			} else if ap, ok := s["additionalProperties"]; ok {
+				validateInstance(ap, val[k], child, errs)
+			}
+		}
+		checkSize(s, "minProperties", "maxProperties", len(val), "properties", path, errs)
+	case []interface{}:
+		prefix, _ := s["prefixItems"].([]interface{})
+		for i, item := range val {
+			child := fmt.Sprintf("%s/%d", path, i)
+			if i < len(prefix) {
+				validateInstance(prefix[i], item, child, errs)
+			} else if items, ok := s["items"]; ok {
+				validateInstance(items, item, child, errs)
+			}
+		}
+		checkSize(s, "minItems", "maxItems", len(val), "items", path, errs)
+	case string:
+		checkSize(s, "minLength", "maxLength", len([]rune(val)), "characters", path, errs)
+		if p, ok := s["pattern"].(string); ok {
+			if re, err := regexp.Compile(p); err == nil && !re.MatchString(val) {
+				errs.addf(path, "must match pattern %q", p)
+			}
+		}
+	case float64:
+		if m, ok := s["minimum"].(float64); ok && val < m {
+			errs.addf(path, "must be >= %g", m)
+		}
+		if m, ok := s["maximum"].(float64); ok && val > m {
+			errs.addf(path, "must be <= %g", m)
+		}
+		if m, ok := s["exclusiveMinimum"].(float64); ok && val <= m {
+			errs.addf(path, "must be > %g", m)
+		}
+		if m, ok := s["exclusiveMaximum"].(float64); ok && val >= m {
+			errs.addf(path, "must be < %g", m)
+		}
+	}
+
+	if all, ok := s["allOf"].([]interface{}); ok {
+		for _, sub := range all {
+			validateInstance(sub, v, path, errs)
+		}
+	}
+	if anyOf, ok := s["anyOf"].([]interface{}); ok && countMatches(anyOf, v, path) == 0 {
+		errs.addf(path, "must match at least one anyOf schema")
+	}
+	if oneOf, ok := s["oneOf"].([]interface{}); ok {
+		if n := countMatches(oneOf, v, path); n != 1 {
+			errs.addf(path, "must match exactly one oneOf schema, matched %d", n)
+		}
+	}
+	if not, ok := s["not"]; ok && countMatches([]interface{}{not}, v, path) == 1 {
+		errs.addf(path, "must not match the not schema")
+	}
+}
+
+// countMatches returns how many of schemas v conforms to.
+func countMatches(schemas []interface{}, v interface{}, path string) int {
+	n := 0
+	for _, sub := range schemas {
+		var sink ValidationErrors
+		validateInstance(sub, v, path, &sink)
+		if len(sink) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// checkSize records a violation of the minKey and maxKey bounds for a value
+// with n elements of the given unit.
+func checkSize(s map[string]interface{}, minKey, maxKey string, n int, unit, path string, errs *ValidationErrors) {
+	if m, ok := s[minKey].(float64); ok && float64(n) < m {
+		errs.addf(path, "must have at least %g %s", m, unit)
+	}
+	if m, ok := s[maxKey].(float64); ok && float64(n) > m {
+		errs.addf(path, "must have at most %g %s", m, unit)
+	}
+}
+
+// matchesSchemaType reports whether v has one of the types named by the
+// "type" keyword value t.
+func matchesSchemaType(t, v interface{}) bool {
+	switch tt := t.(type) {
+	case string:
+		return isSchemaType(tt, v)
+	case []interface{}:
+		for _, item := range tt {
+			if name, ok := item.(string); ok && isSchemaType(name, v) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// isSchemaType reports whether v is of the named JSON Schema type.
+func isSchemaType(name string, v interface{}) bool {
+	switch name {
+	case "null":
+		return v == nil
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "object":
+		_, ok := v.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := v.([]interface{})
+		return ok
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	case "integer":
+		n, ok := v.(float64)
+		return ok && n == float64(int64(n))
+	case "string":
+		_, ok := v.(string)
+		return ok
+	}
+	return false
+}
+
+// formatSchemaType renders a "type" keyword value for messages.
+func formatSchemaType(t interface{}) string {
+	if items, ok := t.([]interface{}); ok {
+		names := make([]string, len(items))
+		for i, item := range items {
+			names[i] = fmt.Sprint(item)
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(t)
+}
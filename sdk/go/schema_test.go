@@ -0,0 +1,129 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestPortValidateSchemaSyntax(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr string
+	}{
+		{"empty", "", ""},
+		{"boolean schema", `true`, ""},
+		{"object", `{"type": "object", "properties": {"n": {"type": "integer", "minimum": 0}}, "required": ["n"]}`, ""},
+		{"type array", `{"type": ["string", "null"]}`, ""},
+		{"nested items", `{"type": "array", "items": {"type": "string", "pattern": "^[a-z]+$"}}`, ""},
+		{"unknown keyword allowed", `{"x-internal": 1}`, ""},
+		{"malformed json", `{"type": `, "parse json"},
+		{"not an object", `"string"`, "schema must be an object or boolean"},
+		{"unknown type", `{"type": "text"}`, `#/type: unknown type "text"`},
+		{"duplicate type", `{"type": ["string", "string"]}`, "duplicate type"},
+		{"properties not object", `{"properties": []}`, "#/properties: must be an object of schemas"},
+		{"nested bad type", `{"properties": {"a": {"type": 5}}}`, "#/properties/a/type"},
+		{"required not strings", `{"required": [1]}`, "#/required: must be an array of strings"},
+		{"negative minLength", `{"minLength": -1}`, "non-negative integer"},
+		{"empty anyOf", `{"anyOf": []}`, "non-empty array of schemas"},
+		{"bad pattern", `{"pattern": "("}`, "invalid pattern"},
+		{"items array", `{"items": [{"type": "string"}, {"type": "bogus"}]}`, "#/items/1/type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Port{Name: "data", Schema: json.RawMessage(tt.schema)}
+			err := p.ValidateSchemaSyntax()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateSchemaSyntax() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("ValidateSchemaSyntax() = nil, want error containing %q", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %q, want it to contain %q", err, tt.wantErr)
+			}
+			if !strings.HasPrefix(err.Error(), "port data:") {
+				t.Errorf("error = %q, want port name prefix", err)
+			}
+		})
+	}
+}
+
+func TestAgentValidateOutput(t *testing.T) {
+	agent := NewAgent("a", "")
+	if err := agent.ValidateOutput([]byte(`"anything"`)); err != nil {
+		t.Errorf("ValidateOutput without schema = %v", err)
+	}
+
+	agent.OutputSchema = json.RawMessage(`{
+		"type": "object",
+		"required": ["summary", "sources"],
+		"properties": {
+			"summary": {"type": "string", "minLength": 1},
+			"confidence": {"type": "number", "minimum": 0, "maximum": 1},
+			"sources": {"type": "array", "minItems": 1, "items": {"type": "string", "pattern": "^https://"}},
+			"status": {"enum": ["ok", "partial"]}
+		},
+		"additionalProperties": false
+	}`)
+	if err := agent.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+
+	valid := `{"summary": "s", "confidence": 0.5, "sources": ["https://example.com"], "status": "ok"}`
+	if err := agent.ValidateOutput([]byte(valid)); err != nil {
+		t.Errorf("ValidateOutput(valid) = %v", err)
+	}
+
+	err := agent.ValidateOutput([]byte(`{"confidence": 2, "sources": ["http://x", 3], "status": "bad", "extra": true}`))
+	want := []string{
+		`#: missing required property "summary"`,
+		`#/confidence: must be <= 1`,
+		`#/extra: no value is allowed`,
+		`#/sources/0: must match pattern "^https://"`,
+		`#/sources/1: must be of type string`,
+		`#/status: must be one of the enum values`,
+	}
+	if err == nil || err.Error() != strings.Join(want, "; ") {
+		t.Errorf("ValidateOutput(invalid) = %v, want %s", err, strings.Join(want, "; "))
+	}
+
+	if err := agent.ValidateOutput([]byte(`{`)); err == nil || !strings.Contains(err.Error(), "parse output") {
+		t.Errorf("ValidateOutput(malformed) = %v", err)
+	}
+}
+
+func TestValidateInstanceCombinators(t *testing.T) {
+	schema := map[string]interface{}{
+		"oneOf": []interface{}{
+			map[string]interface{}{"type": "integer"},
+			map[string]interface{}{"type": "number", "exclusiveMinimum": 10.0},
+		},
+		"not": map[string]interface{}{"const": 3.0},
+	}
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{2.0, ""},
+		{10.5, ""},
+		{12.0, "#: must match exactly one oneOf schema, matched 2"},
+		{3.0, "#: must not match the not schema"},
+	}
+	for _, tt := range tests {
+		var errs ValidationErrors
+		validateInstance(schema, tt.value, "#", &errs)
+		got := ""
+		if err := errs.err(); err != nil {
+			got = err.Error()
+		}
+		if got != tt.want {
+			t.Errorf("validateInstance(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
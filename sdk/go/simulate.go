@@ -0,0 +1,91 @@
+package multiagentspec
+
+import "fmt"
+
+// Sources recorded in SimResult.Inputs for values not produced by a step.
+const (
+	SimSourceExternal = "<external>"
+	SimSourceDefault  = "<default>"
+)
+
+// SimResult records the outcome of a workflow dry run.
+type SimResult struct {
+	// Order is the sequence of steps the simulation executed.
+	Order []string `json:"order"`
+
+	// Inputs maps "step.input" to where its value came from: a producing
+	// "step.output", SimSourceExternal, or SimSourceDefault. Optional inputs
+	// that could not be satisfied are omitted.
+	Inputs map[string]string `json:"inputs"`
+
+	// Outputs maps each executed step to the outputs it would produce.
+	Outputs map[string][]string `json:"outputs"`
+
+	// FailedStep is the first step whose required inputs were unavailable.
+	FailedStep string `json:"failedStep,omitempty"`
+}
+
+// Simulate walks the steps in topological order and verifies that each
+// step's inputs can be satisfied, without running any agents. An input wired
+// with From is satisfied once the referenced step has run and declares that
+// output. Other inputs are satisfied by an entry in inputs keyed by
+// "step.input" or the bare input name, or by the port's Default. Inputs are
+// required unless Required is explicitly false.
+//
+// On failure, Simulate returns the partial result with FailedStep set along
+// with an error describing the first unsatisfiable input.
+func (w *Workflow) Simulate(inputs map[string]interface{}) (*SimResult, error) {
+	order, err := w.TopologicalOrder()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SimResult{
+		Inputs:  make(map[string]string),
+		Outputs: make(map[string][]string),
+	}
+	available := make(map[string]bool)
+
+	for _, step := range order {
+		for _, in := range step.Inputs {
+			key := step.Name + "." + in.Name
+			source := ""
+			switch {
+			case in.From != "" && available[in.From]:
+				source = in.From
+			case hasInput(inputs, key) || (in.From == "" && hasInput(inputs, in.Name)):
+				source = SimSourceExternal
+			case in.Default != nil:
+				source = SimSourceDefault
+			}
+
+			if source != "" {
+				result.Inputs[key] = source
+				continue
+			}
+			if in.Required != nil && !*in.Required {
+				continue
+			}
+
+			result.FailedStep = step.Name
+			if in.From != "" {
+				return result, fmt.Errorf("step %s: input %s is not available: %s was not produced", step.Name, in.Name, in.From)
+			}
+			return result, fmt.Errorf("step %s: required input %s was not provided", step.Name, in.Name)
+		}
+
+		result.Order = append(result.Order, step.Name)
+		for _, out := range step.Outputs {
+			available[step.Name+"."+out.Name] = true
+			result.Outputs[step.Name] = append(result.Outputs[step.Name], out.Name)
+		}
+	}
+
+	return result, nil
+}
+
+// hasInput reports whether inputs contains key.
+func hasInput(inputs map[string]interface{}, key string) bool {
+	_, ok := inputs[key]
+	return ok
+}
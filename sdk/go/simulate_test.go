@@ -0,0 +1,93 @@
+package multiagentspec
+
+import (
+	"strings"
+	"testing"
+)
+
+func simulationWorkflow() *Workflow {
+	optional := false
+	return &Workflow{
+		Type: WorkflowDAG,
+		Steps: []Step{
+			{
+				Name:      "synthesis",
+				Agent:     "synth",
+				Inputs:    []Port{{Name: "urls", From: "research.candidate_urls"}},
+				Outputs:   []Port{{Name: "statistics"}},
+				DependsOn: []string{"research"},
+			},
+			{
+				Name:    "research",
+				Agent:   "researcher",
+				Inputs:  []Port{{Name: "topic"}, {Name: "limit", Default: 10}, {Name: "hint", Required: &optional}},
+				Outputs: []Port{{Name: "candidate_urls"}},
+			},
+		},
+	}
+}
+
+func TestWorkflowSimulate(t *testing.T) {
+	w := simulationWorkflow()
+
+	result, err := w.Simulate(map[string]interface{}{"topic": "climate"})
+	if err != nil {
+		t.Fatalf("Simulate failed: %v", err)
+	}
+
+	if strings.Join(result.Order, ",") != "research,synthesis" {
+		t.Errorf("Order = %v, want [research synthesis]", result.Order)
+	}
+	want := map[string]string{
+		"research.topic": SimSourceExternal,
+		"research.limit": SimSourceDefault,
+		"synthesis.urls": "research.candidate_urls",
+	}
+	if len(result.Inputs) != len(want) {
+		t.Errorf("Inputs = %v, want %v", result.Inputs, want)
+	}
+	for k, v := range want {
+		if result.Inputs[k] != v {
+			t.Errorf("Inputs[%q] = %q, want %q", k, result.Inputs[k], v)
+		}
+	}
+	if got := result.Outputs["synthesis"]; len(got) != 1 || got[0] != "statistics" {
+		t.Errorf("Outputs[synthesis] = %v", got)
+	}
+}
+
+func TestWorkflowSimulateMissingInput(t *testing.T) {
+	w := simulationWorkflow()
+
+	result, err := w.Simulate(nil)
+	if err == nil {
+		t.Fatal("Simulate should fail without the topic input")
+	}
+	if result.FailedStep != "research" {
+		t.Errorf("FailedStep = %q, want research", result.FailedStep)
+	}
+	if !strings.Contains(err.Error(), "required input topic was not provided") {
+		t.Errorf("error = %q", err)
+	}
+
+	// Step-qualified external inputs are accepted too.
+	if _, err := w.Simulate(map[string]interface{}{"research.topic": "x"}); err != nil {
+		t.Errorf("Simulate with qualified input failed: %v", err)
+	}
+}
+
+func TestWorkflowSimulateUnproducedOutput(t *testing.T) {
+	w := simulationWorkflow()
+	w.Steps[1].Outputs = nil
+
+	result, err := w.Simulate(map[string]interface{}{"topic": "x"})
+	if err == nil {
+		t.Fatal("Simulate should fail when an upstream output is not produced")
+	}
+	if result.FailedStep != "synthesis" || len(result.Order) != 1 {
+		t.Errorf("result = %+v", result)
+	}
+	if !strings.Contains(err.Error(), "research.candidate_urls was not produced") {
+		t.Errorf("error = %q", err)
+	}
+}
@@ -0,0 +1,129 @@
+package multiagentspec
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// systemdExecData is the data available to the ExecStart template of
+// GenerateSystemdUnits.
+type systemdExecData struct {
+	Name          string
+	QualifiedName string
+	Namespace     string
+	Model         string
+	Team          string
+}
+
+// GenerateSystemdUnits generates a systemd service unit for each agent in the
+// team, for deployments to VMs or bare metal. The result maps file names
+// ("<agent>.service", with the "/" of a namespaced agent's qualified name
+// replaced by "-") to unit contents. It returns an error if two agents map to
+// the same unit name.
+//
+// ExecStart is rendered from execTemplate, a text/template with the fields
+// .Name, .QualifiedName, .Namespace, .Model, and .Team; for example
+// "/usr/local/bin/agent-runner --agent {{.Name}} --model {{.Model}}". The
+// rendered line is escaped like the other unit values, so a "%" is literal
+// rather than a systemd specifier. Dependencies on other team agents become
+// Requires= and After= ordering, and the agent's Env is set alongside
+// AGENT_NAME and AGENT_MODEL. Systemd is not a Platform, so the model is the
+// agent's Model (defaulting to sonnet) and ModelByPlatform does not apply.
+func GenerateSystemdUnits(team *Team, agents []*Agent, execTemplate string) (map[string][]byte, error) {
+	if strings.TrimSpace(execTemplate) == "" {
+		return nil, fmt.Errorf("systemd units: exec template is required")
+	}
+	tmpl, err := template.New("exec").Option("missingkey=error").Parse(execTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("systemd units: parse exec template: %w", err)
+	}
+
+	members, err := teamMembers(team, agents)
+	if err != nil {
+		return nil, err
+	}
+	unitNames := make(map[string]string, len(members)*2)
+	owners := make(map[string]*Agent, len(members))
+	for _, agent := range members {
+		unit := systemdUnitName(agent)
+		if owner, ok := owners[unit]; ok && owner != agent {
+			return nil, fmt.Errorf("systemd units: agents %s and %s share the unit %s", owner.QualifiedName(), agent.QualifiedName(), unit)
+		}
+		owners[unit] = agent
+		unitNames[agent.Name] = unit
+		unitNames[agent.QualifiedName()] = unit
+	}
+
+	files := make(map[string][]byte, len(members))
+	for _, agent := range members {
+		var exec bytes.Buffer
+		err := tmpl.Execute(&exec, systemdExecData{
+			Name:          agent.Name,
+			QualifiedName: agent.QualifiedName(),
+			Namespace:     agent.Namespace,
+			Model:         string(agent.effectiveModel()),
+			Team:          team.Name,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("agent %s: render exec template: %w", agent.Name, err)
+		}
+		if strings.ContainsAny(exec.String(), "\r\n") {
+			return nil, fmt.Errorf("agent %s: exec template must render to a single line", agent.Name)
+		}
+
+		var deps []string
+		for _, dep := range agent.Dependencies {
+			if unit, ok := unitNames[dependencyName(dep)]; ok && !containsString(deps, unit) {
+				deps = append(deps, unit)
+			}
+		}
+
+		var b strings.Builder
+		b.WriteString("[Unit]\n")
+		description := fmt.Sprintf("%s agent %s", team.Name, agent.Name)
+		if agent.Description != "" {
+			description += ": " + agent.Description
+		}
+		fmt.Fprintf(&b, "Description=%s\n", systemdEscape(description))
+		if len(deps) > 0 {
+			fmt.Fprintf(&b, "Requires=%s\n", strings.Join(deps, " "))
+			fmt.Fprintf(&b, "After=%s\n", strings.Join(deps, " "))
+		}
+
+		b.WriteString("\n[Service]\n")
+		b.WriteString("Type=simple\n")
+		fmt.Fprintf(&b, "ExecStart=%s\n", systemdEscape(exec.String()))
+		env := append([]k8sEnvVar{
+			{Name: "AGENT_NAME", Value: agent.Name},
+			{Name: "AGENT_MODEL", Value: string(agent.effectiveModel())},
+		}, k8sEnv(agent.Env)...)
+		for _, e := range env {
+			fmt.Fprintf(&b, "Environment=\"%s\"\n", systemdQuote(e.Name+"="+e.Value))
+		}
+		b.WriteString("Restart=on-failure\n")
+
+		b.WriteString("\n[Install]\n")
+		b.WriteString("WantedBy=multi-user.target\n")
+
+		files[systemdUnitName(agent)] = []byte(b.String())
+	}
+	return files, nil
+}
+
+// systemdEscape escapes systemd specifiers ("%") and flattens newlines in a
+// unit setting value.
+func systemdEscape(s string) string {
+	return strings.NewReplacer("%", "%%", "\n", " ", "\r", " ").Replace(s)
+}
+
+// systemdUnitName returns the service unit name of an agent.
+func systemdUnitName(a *Agent) string {
+	return strings.ReplaceAll(a.QualifiedName(), "/", "-") + ".service"
+}
+
+// systemdQuote escapes s for use inside a double-quoted systemd value.
+func systemdQuote(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(systemdEscape(s))
+}
@@ -0,0 +1,94 @@
+package multiagentspec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateSystemdUnits(t *testing.T) {
+	team := NewTeam("stats", "1.0.0").WithAgents("research", "synthesis")
+	synthesis := NewAgent("synthesis", "Summarize 100% of findings").WithModel(ModelOpus)
+	synthesis.Dependencies = []string{"research@>=1.0.0", "external-api"}
+	synthesis.Env = map[string]string{"LOG_LEVEL": "debug", "GREETING": `say "hi"`}
+	agents := []*Agent{NewAgent("research", ""), synthesis}
+
+	files, err := GenerateSystemdUnits(team, agents, "/usr/local/bin/agent-runner --agent {{.Name}} --model {{.Model}} --team {{.Team}}")
+	if err != nil {
+		t.Fatalf("GenerateSystemdUnits failed: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("len(files) = %d, want 2", len(files))
+	}
+
+	want := `[Unit]
+Description=stats agent synthesis: Summarize 100%% of findings
+Requires=research.service
+After=research.service
+
+[Service]
+Type=simple
+ExecStart=/usr/local/bin/agent-runner --agent synthesis --model opus --team stats
+Environment="AGENT_NAME=synthesis"
+Environment="AGENT_MODEL=opus"
+Environment="GREETING=say \"hi\""
+Environment="LOG_LEVEL=debug"
+Restart=on-failure
+
+[Install]
+WantedBy=multi-user.target
+`
+	if got := string(files["synthesis.service"]); got != want {
+		t.Errorf("synthesis.service =\n%s\nwant\n%s", got, want)
+	}
+	if strings.Contains(string(files["research.service"]), "After=") {
+		t.Errorf("research.service should have no ordering:\n%s", files["research.service"])
+	}
+}
+
+func TestGenerateSystemdUnitsErrors(t *testing.T) {
+	team := NewTeam("t", "1.0.0").WithAgents("a")
+	agents := []*Agent{NewAgent("a", "")}
+
+	for name, tmpl := range map[string]string{
+		"empty":     "  ",
+		"syntax":    "run {{.Name",
+		"field":     "run {{.Image}}",
+		"multiline": "run {{.Name}}\nrm -rf /",
+	} {
+		if _, err := GenerateSystemdUnits(team, agents, tmpl); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if _, err := GenerateSystemdUnits(NewTeam("t", "1.0.0").WithAgents("b"), agents, "run"); err == nil {
+		t.Error("expected error for agent missing from definitions")
+	}
+}
+
+func TestGenerateSystemdUnitsEscapesExecStart(t *testing.T) {
+	team := NewTeam("t", "1.0.0").WithAgents("a")
+	files, err := GenerateSystemdUnits(team, []*Agent{NewAgent("a", "")}, "run --agent {{.Name}} --load 90%")
+	if err != nil {
+		t.Fatalf("GenerateSystemdUnits failed: %v", err)
+	}
+	if !strings.Contains(string(files["a.service"]), "ExecStart=run --agent a --load 90%%\n") {
+		t.Errorf("ExecStart is not escaped:\n%s", files["a.service"])
+	}
+}
+
+func TestGenerateSystemdUnitsNamespaced(t *testing.T) {
+	team := NewTeam("t", "1.0.0").WithAgents("prd/lead", "dev/lead")
+	agents := []*Agent{NewAgent("lead", "").WithNamespace("prd"), NewAgent("lead", "").WithNamespace("dev")}
+	files, err := GenerateSystemdUnits(team, agents, "run {{.QualifiedName}}")
+	if err != nil {
+		t.Fatalf("GenerateSystemdUnits failed: %v", err)
+	}
+	if _, ok := files["prd-lead.service"]; !ok || len(files) != 2 {
+		t.Errorf("files = %v, want prd-lead.service and dev-lead.service", files)
+	}
+
+	clash := NewTeam("t", "1.0.0").WithAgents("prd/lead", "prd-lead")
+	_, err = GenerateSystemdUnits(clash, []*Agent{agents[0], NewAgent("prd-lead", "")}, "run")
+	if err == nil || !strings.Contains(err.Error(), "share the unit prd-lead.service") {
+		t.Errorf("GenerateSystemdUnits error = %v, want unit collision", err)
+	}
+}
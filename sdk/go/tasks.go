@@ -0,0 +1,108 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// FilterTasks returns the agent's tasks for which pred returns true, in
+// declaration order.
+func (a *Agent) FilterTasks(pred func(Task) bool) []Task {
+	var tasks []Task
+	for _, task := range a.Tasks {
+		if pred(task) {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+// IsRequired reports whether failure of the task causes a NO-GO report.
+// Tasks are required unless Required is explicitly false.
+func (t Task) IsRequired() bool {
+	return t.Required == nil || *t.Required
+}
+
+// TimeoutOrDefault returns the task's TimeoutSeconds, or def if it is unset.
+func (t *Task) TimeoutOrDefault(def int) int {
+	if t.TimeoutSeconds > 0 {
+		return t.TimeoutSeconds
+	}
+	return def
+}
+
+// TaskOfType returns a predicate matching tasks of the given type. Tasks with
+// no Type are treated as TaskTypeManual.
+func TaskOfType(taskType TaskType) func(Task) bool {
+	return func(t Task) bool {
+		if t.Type == "" {
+			return taskType == TaskTypeManual
+		}
+		return t.Type == taskType
+	}
+}
+
+// TaskRequired returns a predicate matching required tasks (see Task.IsRequired).
+func TaskRequired() func(Task) bool {
+	return func(t Task) bool {
+		return t.IsRequired()
+	}
+}
+
+// TaskMatchesFile returns a predicate matching tasks whose File matches glob
+// (using path.Match syntax), or whose Files pattern is exactly glob.
+func TaskMatchesFile(glob string) func(Task) bool {
+	return func(t Task) bool {
+		if t.Files != "" && t.Files == glob {
+			return true
+		}
+		if t.File == "" {
+			return false
+		}
+		ok, err := path.Match(glob, t.File)
+		return err == nil && ok
+	}
+}
+
+// ValidateTaskFiles warns when a file or pattern task references paths that
+// do not exist in fsys: a File that cannot be found, or a Files glob that
+// matches nothing. Paths are resolved relative to the root of fsys.
+func (a *Agent) ValidateTaskFiles(fsys fs.FS) []LintWarning {
+	var warnings []LintWarning
+	for _, task := range a.Tasks {
+		if task.Type != TaskTypeFile && task.Type != TaskTypePattern {
+			continue
+		}
+		p := fmt.Sprintf("tasks[%s]", task.ID)
+		if task.Type == TaskTypeFile && task.File == "" {
+			warnings = append(warnings, LintWarning{Path: p + ".file", Message: "file task has no file"})
+		}
+		if task.File != "" {
+			name := fsPath(task.File)
+			if _, err := fs.Stat(fsys, name); err != nil {
+				warnings = append(warnings, LintWarning{Path: p + ".file", Message: fmt.Sprintf("%s does not exist", task.File)})
+			}
+		}
+		if task.Files != "" {
+			matches, err := fs.Glob(fsys, fsPath(task.Files))
+			switch {
+			case err != nil:
+				warnings = append(warnings, LintWarning{Path: p + ".files", Message: fmt.Sprintf("invalid glob %q: %v", task.Files, err)})
+			case len(matches) == 0:
+				warnings = append(warnings, LintWarning{Path: p + ".files", Message: fmt.Sprintf("%s matches no files", task.Files)})
+			}
+		}
+	}
+	return warnings
+}
+
+// fsPath converts a task path to an fs.FS path by cleaning it and dropping
+// any leading "./" or "/".
+func fsPath(p string) string {
+	if p = strings.TrimPrefix(path.Clean("/"+p), "/"); p == "" {
+		return "."
+	}
+	return p
+}
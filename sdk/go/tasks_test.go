@@ -0,0 +1,102 @@
+package multiagentspec
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func taskIDs(tasks []Task) []string {
+	ids := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+func TestAgentFilterTasks(t *testing.T) {
+	optional := false
+	agent := NewAgent("qa", "")
+	agent.Tasks = []Task{
+		{ID: "build", Type: TaskTypeCommand, Command: "go build ./...", File: "go.mod"},
+		{ID: "lint", Type: TaskTypeCommand, Command: "golangci-lint run", Required: &optional},
+		{ID: "todos", Type: TaskTypePattern, Pattern: "TODO", Files: "**/*.go"},
+		{ID: "license", Type: TaskTypeFile, File: "LICENSE"},
+		{ID: "review"},
+	}
+
+	tests := []struct {
+		name string
+		pred func(Task) bool
+		want string
+	}{
+		{"command", TaskOfType(TaskTypeCommand), "build,lint"},
+		{"manual default", TaskOfType(TaskTypeManual), "review"},
+		{"required", TaskRequired(), "build,todos,license,review"},
+		{"file glob", TaskMatchesFile("go.*"), "build"},
+		{"files pattern", TaskMatchesFile("**/*.go"), "todos"},
+		{"required commands", func(t Task) bool {
+			return TaskOfType(TaskTypeCommand)(t) && TaskRequired()(t)
+		}, "build"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := taskIDs(agent.FilterTasks(tt.pred))
+			if joined := strings.Join(got, ","); joined != tt.want {
+				t.Errorf("FilterTasks() = %s, want %s", joined, tt.want)
+			}
+		})
+	}
+}
+
+func TestAgentValidateTaskFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"go.mod":           {Data: []byte("module x")},
+		"cmd/main.go":      {Data: []byte("package main")},
+		"docs/README.md":   {Data: []byte("# x")},
+		"internal/util.go": {Data: []byte("package internal")},
+	}
+	agent := NewAgent("a", "")
+	agent.Tasks = []Task{
+		{ID: "mod", Type: TaskTypeFile, File: "./go.mod"},
+		{ID: "license", Type: TaskTypeFile, File: "LICENSE"},
+		{ID: "nofile", Type: TaskTypeFile},
+		{ID: "todos", Type: TaskTypePattern, Pattern: "TODO", Files: "*/*.go"},
+		{ID: "ts", Type: TaskTypePattern, Pattern: "any", Files: "src/*.ts"},
+		{ID: "bad", Type: TaskTypePattern, Files: "[", File: "/docs/README.md"},
+		{ID: "cmd", Type: TaskTypeCommand, Command: "go test", File: "missing"},
+	}
+
+	var got []string
+	for _, w := range agent.ValidateTaskFiles(fsys) {
+		got = append(got, w.String())
+	}
+	want := []string{
+		"tasks[license].file: LICENSE does not exist",
+		"tasks[nofile].file: file task has no file",
+		"tasks[ts].files: src/*.ts matches no files",
+		`tasks[bad].files: invalid glob "[": syntax error in pattern`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("ValidateTaskFiles() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestTaskTimeout(t *testing.T) {
+	task := Task{ID: "build", Type: TaskTypeCommand}
+	if got := task.TimeoutOrDefault(300); got != 300 {
+		t.Errorf("TimeoutOrDefault() = %d, want 300", got)
+	}
+	task.TimeoutSeconds = 60
+	if got := task.TimeoutOrDefault(300); got != 60 {
+		t.Errorf("TimeoutOrDefault() = %d, want 60", got)
+	}
+
+	agent := NewAgent("a", "")
+	agent.Tasks = []Task{task, {ID: "lint", TimeoutSeconds: -5}}
+	err := agent.Validate()
+	if err == nil || err.Error() != "tasks[1].timeout_seconds: must be non-negative, got -5" {
+		t.Errorf("Validate() = %v", err)
+	}
+}
@@ -1,6 +1,10 @@
 package multiagentspec
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
 
 // WorkflowType represents the workflow execution pattern.
 type WorkflowType string
@@ -12,6 +16,19 @@ const (
 	WorkflowOrchestrated WorkflowType = "orchestrated"
 )
 
+// workflowTypes lists every WorkflowType.
+var workflowTypes = []WorkflowType{WorkflowSequential, WorkflowParallel, WorkflowDAG, WorkflowOrchestrated}
+
+// Valid reports whether t is a known workflow type.
+func (t WorkflowType) Valid() bool {
+	for _, known := range workflowTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
 // PortType represents the data type of a port.
 type PortType string
 
@@ -46,6 +63,10 @@ type Port struct {
 
 	// Default is the default value if not provided (inputs only).
 	Default interface{} `json:"default,omitempty"`
+
+	// Transform is a JSONPath expression (e.g., $.results[0].url) applied to
+	// the data before it is consumed, to rename or pick fields between steps.
+	Transform string `json:"transform,omitempty"`
 }
 
 // Step represents a workflow step definition.
@@ -64,6 +85,32 @@ type Step struct {
 
 	// Outputs are typed data outputs produced by this step.
 	Outputs []Port `json:"outputs,omitempty"`
+
+	// Resources overrides the deployment's resource limits for this step.
+	Resources *ResourceLimits `json:"resources,omitempty"`
+
+	// Deterministic indicates the step produces the same outputs for the same
+	// inputs. Steps with external effects (e.g., web search) should set false.
+	Deterministic *bool `json:"deterministic,omitempty"`
+}
+
+// ResourcesOrDefault returns the step's resource limits, using the
+// corresponding field of def for any limit the step leaves unset.
+func (s *Step) ResourcesOrDefault(def ResourceLimits) ResourceLimits {
+	if s.Resources == nil {
+		return def
+	}
+	limits := *s.Resources
+	if limits.CPU == "" {
+		limits.CPU = def.CPU
+	}
+	if limits.Memory == "" {
+		limits.Memory = def.Memory
+	}
+	if limits.GPU == 0 {
+		limits.GPU = def.GPU
+	}
+	return limits
 }
 
 // Workflow represents a workflow definition.
@@ -73,6 +120,51 @@ type Workflow struct {
 
 	// Steps are the ordered steps in the workflow.
 	Steps []Step `json:"steps,omitempty"`
+
+	// Budget bounds the duration, cost, and size of the whole workflow.
+	Budget *WorkflowBudget `json:"budget,omitempty"`
+}
+
+// WorkflowBudget bounds a whole workflow run. Zero values mean no limit.
+type WorkflowBudget struct {
+	// MaxDurationSeconds is the overall deadline for a run.
+	MaxDurationSeconds int `json:"max_duration_seconds,omitempty"`
+
+	// MaxCostUSD is the maximum model spend for a run, in US dollars.
+	MaxCostUSD float64 `json:"max_cost_usd,omitempty"`
+
+	// MaxSteps is the maximum number of steps the workflow may declare.
+	MaxSteps int `json:"max_steps,omitempty"`
+}
+
+// Validate checks that no budget limit is negative.
+func (b *WorkflowBudget) Validate() error {
+	var errs ValidationErrors
+	if b.MaxDurationSeconds < 0 {
+		errs.addf("max_duration_seconds", "must be non-negative, got %d", b.MaxDurationSeconds)
+	}
+	if b.MaxCostUSD < 0 {
+		errs.addf("max_cost_usd", "must be non-negative, got %g", b.MaxCostUSD)
+	}
+	if b.MaxSteps < 0 {
+		errs.addf("max_steps", "must be non-negative, got %d", b.MaxSteps)
+	}
+	return errs.err()
+}
+
+// Protocol declares the format of messages sent from one agent to another.
+type Protocol struct {
+	// From is the name of the sending agent.
+	From string `json:"from"`
+
+	// To is the name of the receiving agent.
+	To string `json:"to"`
+
+	// Description is a human-readable description of the exchange.
+	Description string `json:"description,omitempty"`
+
+	// Schema is a JSON Schema for the message format.
+	Schema json.RawMessage `json:"schema,omitempty"`
 }
 
 // Team represents a team definition.
@@ -97,6 +189,9 @@ type Team struct {
 
 	// Context is shared background information for all agents.
 	Context string `json:"context,omitempty"`
+
+	// Protocols declare the message formats exchanged between team agents.
+	Protocols []Protocol `json:"protocols,omitempty"`
 }
 
 // NewTeam creates a new Team with the given name and version.
@@ -125,3 +220,206 @@ func (t *Team) WithWorkflow(workflow *Workflow) *Team {
 	t.Workflow = workflow
 	return t
 }
+
+// Validate checks that the team definition is well-formed: name and version
+// are set, agents are unique, a workflow has a known type and at least one
+// step, and the orchestrator and every workflow step refer to team agents. It returns ValidationErrors describing every problem
+// found.
+func (t *Team) Validate() error {
+	var errs ValidationErrors
+	if t.Name == "" {
+		errs.addf("name", "is required")
+	}
+	if t.Version == "" {
+		errs.addf("version", "is required")
+	}
+
+	members := make(map[string]bool, len(t.Agents))
+	for i, name := range t.Agents {
+		path := fmt.Sprintf("agents[%d]", i)
+		switch {
+		case name == "":
+			errs.addf(path, "is required")
+		case members[name]:
+			errs.addf(path, "duplicate agent %q", name)
+		}
+		members[name] = true
+	}
+
+	if t.Orchestrator != "" && !members[t.Orchestrator] {
+		errs.addf("orchestrator", "%q is not a team agent", t.Orchestrator)
+	}
+
+	if t.Workflow != nil {
+		switch {
+		case t.Workflow.Type == "":
+			errs.addf("workflow.type", "is required (allowed: %s, %s, %s, %s)",
+				WorkflowSequential, WorkflowParallel, WorkflowDAG, WorkflowOrchestrated)
+		case !t.Workflow.Type.Valid():
+			errs.addf("workflow.type", "unknown workflow type %q (allowed: %s, %s, %s, %s)", t.Workflow.Type,
+				WorkflowSequential, WorkflowParallel, WorkflowDAG, WorkflowOrchestrated)
+		}
+		if len(t.Workflow.Steps) == 0 {
+			errs.addf("workflow.steps", "must contain at least one step")
+		}
+		errs.add("", t.ValidateWorkflowAgents())
+		errs.add("workflow", t.Workflow.Validate())
+	}
+	return errs.err()
+}
+
+// ValidateWorkflowAgents checks that every workflow step that names an agent
+// names one listed in t.Agents. It returns ValidationErrors naming each
+// offending step and agent.
+func (t *Team) ValidateWorkflowAgents() error {
+	if t.Workflow == nil {
+		return nil
+	}
+	members := make(map[string]bool, len(t.Agents))
+	for _, name := range t.Agents {
+		members[name] = true
+	}
+	var errs ValidationErrors
+	for i, step := range t.Workflow.Steps {
+		if step.Agent != "" && !members[step.Agent] {
+			errs.addf(fmt.Sprintf("workflow.steps[%d].agent", i), "step %q uses %q, which is not a team agent", step.Name, step.Agent)
+		}
+	}
+	return errs.err()
+}
+
+// CaseInsensitiveNameCollisions returns groups of team agents whose qualified
+// names differ only by case, such as "Reviewer" and "reviewer", which clobber
+// each other when written to a case-insensitive filesystem. Agents are
+// resolved through registry; names it does not know are compared as listed.
+// Groups and the names within them follow team order.
+func (t *Team) CaseInsensitiveNameCollisions(registry *AgentRegistry) [][]string {
+	groups := make(map[string][]string)
+	var order []string
+	seen := make(map[string]bool, len(t.Agents))
+	for _, name := range t.Agents {
+		if agent, ok := registry.Get(name); ok {
+			name = agent.QualifiedName()
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		key := strings.ToLower(name)
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], name)
+	}
+
+	var collisions [][]string
+	for _, key := range order {
+		if len(groups[key]) > 1 {
+			collisions = append(collisions, groups[key])
+		}
+	}
+	return collisions
+}
+
+// ContextSeparator separates an inlined team Context from an agent's own
+// Instructions (see InlineContextToAgents).
+const ContextSeparator = "\n\n---\n\n"
+
+// InlineContextToAgents returns clones of the team's agents, resolved through
+// registry in team order, with the team's Context prepended to each agent's
+// Instructions and separated by ContextSeparator. This adapts teams to
+// runtimes that take only per-agent prompts. The registered agents are not
+// modified. Without a Context the clones carry their instructions unchanged.
+func (t *Team) InlineContextToAgents(registry *AgentRegistry) ([]*Agent, error) {
+	agents, err := registry.Resolve(t.Agents)
+	if err != nil {
+		return nil, fmt.Errorf("team %s: %w", t.Name, err)
+	}
+	clones := make([]*Agent, len(agents))
+	for i, agent := range agents {
+		c := agent.Clone()
+		if t.Context != "" {
+			if c.Instructions == "" {
+				c.Instructions = t.Context
+			} else {
+				c.Instructions = t.Context + ContextSeparator + c.Instructions
+			}
+		}
+		clones[i] = c
+	}
+	return clones, nil
+}
+
+// MaxConcurrentAgents returns the largest number of distinct agents active
+// at once while the team's workflow runs. Steps in the same stage (see
+// Workflow.Stages) run concurrently, except in sequential workflows, which
+// run one step at a time. The team's Orchestrator, if set, is counted as
+// active throughout. It returns an error if the workflow's dependencies are
+// invalid.
+func (t *Team) MaxConcurrentAgents() (int, error) {
+	base := 0
+	if t.Orchestrator != "" {
+		base = 1
+	}
+	if t.Workflow == nil || len(t.Workflow.Steps) == 0 {
+		return base, nil
+	}
+
+	stages, err := t.Workflow.Stages()
+	if err != nil {
+		return 0, fmt.Errorf("team %s: %w", t.Name, err)
+	}
+	if t.Workflow.Type == WorkflowSequential {
+		stages = stages[:0]
+		for _, step := range t.Workflow.Steps {
+			stages = append(stages, []Step{step})
+		}
+	}
+
+	most := base
+	for _, stage := range stages {
+		active := make(map[string]bool, len(stage)+1)
+		if t.Orchestrator != "" {
+			active[t.Orchestrator] = true
+		}
+		for _, step := range stage {
+			if step.Agent != "" {
+				active[step.Agent] = true
+			}
+		}
+		most = max(most, len(active))
+	}
+	return most, nil
+}
+
+// Build validates the team and returns it, or returns an error if it is
+// invalid. It is intended as the final call of a builder chain:
+//
+//	team, err := NewTeam("review-team", "1.0.0").WithAgents("lead", "reviewer").Build()
+func (t *Team) Build() (*Team, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ValidateProtocols checks that every protocol names a sending and receiving
+// agent registered in registry, and that its Schema is a well-formed JSON
+// Schema. It returns ValidationErrors describing every problem found.
+func (t *Team) ValidateProtocols(registry *AgentRegistry) error {
+	var errs ValidationErrors
+	for i, p := range t.Protocols {
+		path := fmt.Sprintf("protocols[%d]", i)
+		for _, end := range []struct{ field, name string }{{"from", p.From}, {"to", p.To}} {
+			if end.name == "" {
+				errs.addf(path+"."+end.field, "is required")
+			} else if _, ok := registry.Get(end.name); !ok {
+				errs.addf(path+"."+end.field, "unknown agent %q", end.name)
+			}
+		}
+		if len(p.Schema) > 0 {
+			errs.add(path+".schema", validateSchemaSyntax(p.Schema))
+		}
+	}
+	return errs.err()
+}
@@ -2,6 +2,7 @@ package multiagentspec
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 )
 
@@ -214,3 +215,236 @@ func TestTeamJSONSerialization(t *testing.T) {
 		t.Errorf("len(Agents) = %d, want 2", len(decoded.Agents))
 	}
 }
+
+func TestTeamValidateEmptyWorkflow(t *testing.T) {
+	team := NewTeam("t", "1.0.0").WithAgents("a").WithWorkflow(&Workflow{Type: "pipeline"})
+	err := team.Validate()
+	if err == nil {
+		t.Fatal("Validate() = nil, want error")
+	}
+	want := `workflow.type: unknown workflow type "pipeline" (allowed: sequential, parallel, dag, orchestrated); workflow.steps: must contain at least one step`
+	if err.Error() != want {
+		t.Errorf("Validate() = %q, want %q", err, want)
+	}
+
+	team.Workflow = &Workflow{Steps: []Step{{Name: "s", Agent: "a"}}}
+	want = "workflow.type: is required (allowed: sequential, parallel, dag, orchestrated)"
+	if err := team.Validate(); err == nil || err.Error() != want {
+		t.Errorf("Validate() = %v, want %q for untyped workflow", err, want)
+	}
+
+	team.Workflow.Type = WorkflowSequential
+	if err := team.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil for typed workflow with steps", err)
+	}
+}
+
+func TestStepResourcesOrDefault(t *testing.T) {
+	def := ResourceLimits{CPU: "500m", Memory: "512Mi"}
+
+	step := Step{Name: "format", Agent: "a"}
+	if got := step.ResourcesOrDefault(def); got != def {
+		t.Errorf("ResourcesOrDefault() = %+v, want %+v", got, def)
+	}
+
+	step.Resources = &ResourceLimits{Memory: "8Gi", GPU: 1}
+	want := ResourceLimits{CPU: "500m", Memory: "8Gi", GPU: 1}
+	if got := step.ResourcesOrDefault(def); got != want {
+		t.Errorf("ResourcesOrDefault() = %+v, want %+v", got, want)
+	}
+}
+
+func TestTeamValidate(t *testing.T) {
+	team := NewTeam("stats", "1.0.0").
+		WithAgents("lead", "research", "lead", "").
+		WithOrchestrator("boss").
+		WithWorkflow(&Workflow{Steps: []Step{
+			{Name: "research", Agent: "research"},
+			{Name: "research", Agent: "writer"},
+			{Agent: "lead", Resources: &ResourceLimits{CPU: "many"}},
+		}})
+
+	err := team.Validate()
+	if err == nil {
+		t.Fatal("Validate() = nil, want error")
+	}
+	for _, want := range []string{
+		`agents[2]: duplicate agent "lead"`,
+		"agents[3]: is required",
+		`orchestrator: "boss" is not a team agent`,
+		`workflow.steps[1].name: duplicate step name "research"`,
+		`workflow.steps[1].agent: step "research" uses "writer", which is not a team agent`,
+		"workflow.steps[2].name: is required",
+		"workflow.steps[2].resources.cpu: invalid quantity",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %q, want it to contain %q", err, want)
+		}
+	}
+
+	if err := (&Team{}).Validate(); err == nil || !strings.Contains(err.Error(), "version: is required") {
+		t.Errorf("Validate() = %v, want name and version errors", err)
+	}
+}
+
+func TestTeamBuild(t *testing.T) {
+	team, err := NewTeam("t", "1.0.0").WithAgents("lead").WithOrchestrator("lead").Build()
+	if err != nil || team == nil {
+		t.Fatalf("Build() = %v, %v", team, err)
+	}
+	if team, err := NewTeam("t", "1.0.0").WithOrchestrator("lead").Build(); err == nil || team != nil {
+		t.Errorf("Build() = %v, %v; want error", team, err)
+	}
+}
+
+func TestTeamValidateProtocols(t *testing.T) {
+	registry := newTestRegistry(t, NewAgent("lead", ""), NewAgent("research", ""))
+
+	team := NewTeam("t", "1.0.0").WithAgents("lead", "research")
+	team.Protocols = []Protocol{
+		{From: "lead", To: "research", Schema: json.RawMessage(`{"type":"object","required":["topic"]}`)},
+		{From: "lead", To: "ghost"},
+		{To: "lead", Schema: json.RawMessage(`{"type":"text"}`)},
+	}
+
+	err := team.ValidateProtocols(registry)
+	if err == nil {
+		t.Fatal("ValidateProtocols() = nil, want error")
+	}
+	for _, want := range []string{
+		`protocols[1].to: unknown agent "ghost"`,
+		"protocols[2].from: is required",
+		"protocols[2].schema: ",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("ValidateProtocols() = %q, want it to contain %q", err, want)
+		}
+	}
+
+	team.Protocols = team.Protocols[:1]
+	if err := team.ValidateProtocols(registry); err != nil {
+		t.Errorf("ValidateProtocols() = %v, want nil", err)
+	}
+}
+
+func TestProtocolSerialization(t *testing.T) {
+	team := NewTeam("t", "1.0.0")
+	team.Protocols = []Protocol{{From: "a", To: "b", Schema: json.RawMessage(`{"type":"string"}`)}}
+
+	data, err := json.Marshal(team)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"protocols":[{"from":"a","to":"b","schema":{"type":"string"}}]`) {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+}
+
+func TestTeamCaseInsensitiveNameCollisions(t *testing.T) {
+	registry := NewAgentRegistry()
+	for _, a := range []*Agent{
+		NewAgent("Reviewer", ""),
+		NewAgent("reviewer", ""),
+		NewAgent("lead", ""),
+		NewAgent("Writer", "").WithNamespace("docs"),
+	} {
+		if err := registry.Register(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	team := NewTeam("t", "1.0.0").WithAgents("Reviewer", "lead", "reviewer", "docs/Writer", "DOCS/writer", "reviewer")
+	got := team.CaseInsensitiveNameCollisions(registry)
+	if len(got) != 2 {
+		t.Fatalf("CaseInsensitiveNameCollisions() = %v, want 2 groups", got)
+	}
+	if strings.Join(got[0], ",") != "Reviewer,reviewer" || strings.Join(got[1], ",") != "docs/Writer,DOCS/writer" {
+		t.Errorf("CaseInsensitiveNameCollisions() = %v", got)
+	}
+
+	if got := NewTeam("t", "1.0.0").WithAgents("lead").CaseInsensitiveNameCollisions(registry); got != nil {
+		t.Errorf("CaseInsensitiveNameCollisions() = %v, want nil", got)
+	}
+}
+
+func TestTeamInlineContextToAgents(t *testing.T) {
+	registry := NewAgentRegistry()
+	lead := NewAgent("lead", "").WithInstructions("Coordinate the team.").WithTools("Task")
+	for _, a := range []*Agent{lead, NewAgent("research", "")} {
+		if err := registry.Register(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	team := NewTeam("t", "1.0.0").WithAgents("lead", "research")
+	team.Context = "All figures are in EUR."
+
+	agents, err := team.InlineContextToAgents(registry)
+	if err != nil {
+		t.Fatalf("InlineContextToAgents failed: %v", err)
+	}
+	if agents[0].Instructions != "All figures are in EUR."+ContextSeparator+"Coordinate the team." {
+		t.Errorf("lead instructions = %q", agents[0].Instructions)
+	}
+	if agents[1].Instructions != "All figures are in EUR." {
+		t.Errorf("research instructions = %q", agents[1].Instructions)
+	}
+	agents[0].Tools[0] = "Bash"
+	if lead.Instructions != "Coordinate the team." || lead.Tools[0] != "Task" {
+		t.Errorf("original agent modified: %+v", lead)
+	}
+
+	if _, err := NewTeam("t", "1.0.0").WithAgents("ghost").InlineContextToAgents(registry); err == nil {
+		t.Error("expected error for unregistered agent")
+	}
+}
+
+func TestTeamValidateWorkflowAgents(t *testing.T) {
+	team := NewTeam("t", "1.0.0").WithAgents("lead").WithWorkflow(&Workflow{Steps: []Step{
+		{Name: "plan", Agent: "lead"},
+		{Name: "draft", Agent: "writer"},
+		{Name: "review", Agent: "qa"},
+	}})
+	err := team.ValidateWorkflowAgents()
+	want := `workflow.steps[1].agent: step "draft" uses "writer", which is not a team agent; ` +
+		`workflow.steps[2].agent: step "review" uses "qa", which is not a team agent`
+	if err == nil || err.Error() != want {
+		t.Errorf("ValidateWorkflowAgents() = %v, want %q", err, want)
+	}
+	if err := NewTeam("t", "1.0.0").ValidateWorkflowAgents(); err != nil {
+		t.Errorf("ValidateWorkflowAgents() = %v for team without workflow", err)
+	}
+}
+
+func TestTeamMaxConcurrentAgents(t *testing.T) {
+	steps := []Step{
+		{Name: "plan", Agent: "lead"},
+		{Name: "search", Agent: "searcher", DependsOn: []string{"plan"}},
+		{Name: "scrape", Agent: "scraper", DependsOn: []string{"plan"}},
+		{Name: "verify", Agent: "searcher", DependsOn: []string{"plan"}},
+		{Name: "write", Agent: "writer", DependsOn: []string{"search", "scrape", "verify"}},
+	}
+	tests := []struct {
+		name string
+		team *Team
+		want int
+	}{
+		{"orchestrated", NewTeam("t", "1.0.0").WithOrchestrator("lead").WithWorkflow(&Workflow{Type: WorkflowOrchestrated, Steps: steps}), 3},
+		{"dag without orchestrator", NewTeam("t", "1.0.0").WithWorkflow(&Workflow{Type: WorkflowDAG, Steps: steps}), 2},
+		{"sequential", NewTeam("t", "1.0.0").WithOrchestrator("lead").WithWorkflow(&Workflow{Type: WorkflowSequential, Steps: steps}), 2},
+		{"no workflow", NewTeam("t", "1.0.0").WithOrchestrator("lead"), 1},
+	}
+	for _, tt := range tests {
+		got, err := tt.team.MaxConcurrentAgents()
+		if err != nil || got != tt.want {
+			t.Errorf("%s: MaxConcurrentAgents() = %d, %v, want %d", tt.name, got, err, tt.want)
+		}
+	}
+
+	cyclic := NewTeam("t", "1.0.0").WithWorkflow(&Workflow{Steps: []Step{
+		{Name: "a", Agent: "x", DependsOn: []string{"b"}},
+		{Name: "b", Agent: "x", DependsOn: []string{"a"}},
+	}})
+	if _, err := cyclic.MaxConcurrentAgents(); err == nil {
+		t.Error("MaxConcurrentAgents should fail for a cyclic workflow")
+	}
+}
@@ -0,0 +1,196 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// pathSegmentKind identifies the kind of a JSONPath segment.
+type pathSegmentKind int
+
+const (
+	segmentKey pathSegmentKind = iota
+	segmentIndex
+	segmentWildcard
+)
+
+// pathSegment is a single step in a parsed JSONPath expression.
+type pathSegment struct {
+	kind  pathSegmentKind
+	key   string
+	index int
+}
+
+// ValidateTransform checks that Transform, if set, is a supported JSONPath
+// expression.
+func (p *Port) ValidateTransform() error {
+	if p.Transform == "" {
+		return nil
+	}
+	if _, err := parseJSONPath(p.Transform); err != nil {
+		return fmt.Errorf("port %s: invalid transform: %w", p.Name, err)
+	}
+	return nil
+}
+
+// ApplyTransform evaluates Transform against input and returns the selected
+// value. Input is first normalized to JSON types (maps, slices, float64, etc).
+// If Transform is empty, input is returned unchanged.
+//
+// The supported JSONPath subset is: the root "$", child keys (".name" or
+// "['name']"), array indexes ("[0]", "[-1]" for the last element), and
+// wildcards ("[*]" or ".*") which select every element and yield an array.
+func (p *Port) ApplyTransform(input interface{}) (interface{}, error) {
+	if p.Transform == "" {
+		return input, nil
+	}
+	segments, err := parseJSONPath(p.Transform)
+	if err != nil {
+		return nil, fmt.Errorf("port %s: invalid transform: %w", p.Name, err)
+	}
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("port %s: marshal input: %w", p.Name, err)
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("port %s: normalize input: %w", p.Name, err)
+	}
+
+	result, err := evalJSONPath(value, segments, "$")
+	if err != nil {
+		return nil, fmt.Errorf("port %s: apply transform: %w", p.Name, err)
+	}
+	return result, nil
+}
+
+// parseJSONPath parses an expression in the supported JSONPath subset.
+func parseJSONPath(expr string) ([]pathSegment, error) {
+	if !strings.HasPrefix(expr, "$") {
+		return nil, fmt.Errorf("expression %q must start with $", expr)
+	}
+
+	var segments []pathSegment
+	rest := expr[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			name := rest[:end]
+			rest = rest[end:]
+			switch name {
+			case "":
+				return nil, fmt.Errorf("expression %q: empty key after '.'", expr)
+			case "*":
+				segments = append(segments, pathSegment{kind: segmentWildcard})
+			default:
+				segments = append(segments, pathSegment{kind: segmentKey, key: name})
+			}
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("expression %q: unclosed '['", expr)
+			}
+			inner := rest[1:end]
+			rest = rest[end+1:]
+			seg, err := parseBracket(inner)
+			if err != nil {
+				return nil, fmt.Errorf("expression %q: %w", expr, err)
+			}
+			segments = append(segments, seg)
+		default:
+			return nil, fmt.Errorf("expression %q: unexpected %q", expr, rest[0])
+		}
+	}
+	return segments, nil
+}
+
+// parseBracket parses the contents of a bracket segment.
+func parseBracket(inner string) (pathSegment, error) {
+	if inner == "*" {
+		return pathSegment{kind: segmentWildcard}, nil
+	}
+	if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') {
+		if inner[len(inner)-1] != inner[0] {
+			return pathSegment{}, fmt.Errorf("unterminated quoted key %s", inner)
+		}
+		return pathSegment{kind: segmentKey, key: inner[1 : len(inner)-1]}, nil
+	}
+	index, err := strconv.Atoi(inner)
+	if err != nil {
+		return pathSegment{}, fmt.Errorf("invalid index %q", inner)
+	}
+	return pathSegment{kind: segmentIndex, index: index}, nil
+}
+
+// evalJSONPath applies segments to value. Path is the location of value and
+// is used in error messages.
+func evalJSONPath(value interface{}, segments []pathSegment, path string) (interface{}, error) {
+	if len(segments) == 0 {
+		return value, nil
+	}
+	seg, rest := segments[0], segments[1:]
+
+	switch seg.kind {
+	case segmentKey:
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s is not an object", path)
+		}
+		child, ok := obj[seg.key]
+		if !ok {
+			return nil, fmt.Errorf("%s has no key %q", path, seg.key)
+		}
+		return evalJSONPath(child, rest, path+"."+seg.key)
+
+	case segmentIndex:
+		arr, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s is not an array", path)
+		}
+		i := seg.index
+		if i < 0 {
+			i += len(arr)
+		}
+		if i < 0 || i >= len(arr) {
+			return nil, fmt.Errorf("%s index %d out of range (length %d)", path, seg.index, len(arr))
+		}
+		return evalJSONPath(arr[i], rest, fmt.Sprintf("%s[%d]", path, i))
+
+	default: // segmentWildcard
+		var elems []interface{}
+		switch v := value.(type) {
+		case []interface{}:
+			elems = v
+		case map[string]interface{}:
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				elems = append(elems, v[k])
+			}
+		default:
+			return nil, fmt.Errorf("%s is not an array or object", path)
+		}
+
+		results := make([]interface{}, 0, len(elems))
+		for i, elem := range elems {
+			r, err := evalJSONPath(elem, rest, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, r)
+		}
+		return results, nil
+	}
+}
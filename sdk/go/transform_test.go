@@ -0,0 +1,98 @@
+package multiagentspec
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPortApplyTransform(t *testing.T) {
+	input := map[string]interface{}{
+		"results": []interface{}{
+			map[string]interface{}{"url": "https://a.example", "score": 0.9},
+			map[string]interface{}{"url": "https://b.example", "score": 0.4},
+		},
+		"meta": map[string]interface{}{"total count": 2.0},
+	}
+
+	tests := []struct {
+		transform string
+		want      interface{}
+	}{
+		{"", input},
+		{"$", input},
+		{"$.results[0].url", "https://a.example"},
+		{"$.results[-1].score", 0.4},
+		{"$['meta']['total count']", float64(2)},
+		{"$.results[*].url", []interface{}{"https://a.example", "https://b.example"}},
+		{"$.results.*.score", []interface{}{0.9, 0.4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.transform, func(t *testing.T) {
+			p := Port{Name: "in", Transform: tt.transform}
+			got, err := p.ApplyTransform(input)
+			if err != nil {
+				t.Fatalf("ApplyTransform failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApplyTransform() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPortApplyTransformStruct(t *testing.T) {
+	type result struct {
+		Name string `json:"name"`
+	}
+	p := Port{Name: "in", Transform: "$.name"}
+	got, err := p.ApplyTransform(result{Name: "x"})
+	if err != nil {
+		t.Fatalf("ApplyTransform failed: %v", err)
+	}
+	if got != "x" {
+		t.Errorf("ApplyTransform() = %v, want x", got)
+	}
+}
+
+func TestPortApplyTransformErrors(t *testing.T) {
+	input := map[string]interface{}{"list": []interface{}{1}}
+
+	tests := []struct {
+		transform string
+		wantErr   string
+	}{
+		{"$.missing", `has no key "missing"`},
+		{"$.list[3]", "out of range"},
+		{"$.list.name", "$.list is not an object"},
+		{"$[0]", "$ is not an array"},
+		{"list", "must start with $"},
+	}
+
+	for _, tt := range tests {
+		p := Port{Name: "in", Transform: tt.transform}
+		_, err := p.ApplyTransform(input)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("ApplyTransform(%q) error = %v, want %q", tt.transform, err, tt.wantErr)
+		}
+	}
+}
+
+func TestPortValidateTransform(t *testing.T) {
+	valid := []string{"", "$", "$.a.b", "$['a-b'][2]", "$.items[*].name"}
+	for _, expr := range valid {
+		p := Port{Name: "p", Transform: expr}
+		if err := p.ValidateTransform(); err != nil {
+			t.Errorf("ValidateTransform(%q) = %v, want nil", expr, err)
+		}
+	}
+
+	invalid := []string{"a.b", "$.", "$[", "$[abc]", "$['a]", "$x", "$..a"}
+	for _, expr := range invalid {
+		p := Port{Name: "p", Transform: expr}
+		if err := p.ValidateTransform(); err == nil {
+			t.Errorf("ValidateTransform(%q) = nil, want error", expr)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package multiagentspec
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ValidationError describes a single problem found while validating a definition.
+type ValidationError struct {
+	// Path locates the offending field (e.g., "memory.ttlSeconds").
+	Path string `json:"path"`
+
+	// Message describes the problem.
+	Message string `json:"message"`
+}
+
+// Error implements the error interface.
+func (e ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors collects every problem found while validating a definition.
+type ValidationErrors []ValidationError
+
+// Error implements the error interface.
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, ve := range e {
+		msgs[i] = ve.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// addf records a problem at path.
+func (e *ValidationErrors) addf(path, format string, args ...interface{}) {
+	*e = append(*e, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// add records err at path. Nested ValidationErrors are flattened with their
+// paths prefixed by path. A nil err is ignored.
+func (e *ValidationErrors) add(path string, err error) {
+	if err == nil {
+		return
+	}
+	var nested ValidationErrors
+	if errors.As(err, &nested) {
+		for _, ve := range nested {
+			*e = append(*e, ValidationError{Path: joinPath(path, ve.Path), Message: ve.Message})
+		}
+		return
+	}
+	*e = append(*e, ValidationError{Path: path, Message: err.Error()})
+}
+
+// err returns e as an error, or nil if no problems were recorded.
+func (e ValidationErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// joinPath joins two validation paths with a dot, omitting empty parts.
+// Index segments such as "[0]" are appended without a separator.
+func joinPath(prefix, path string) string {
+	switch {
+	case prefix == "":
+		return path
+	case path == "":
+		return prefix
+	case strings.HasPrefix(path, "["):
+		return prefix + path
+	default:
+		return prefix + "." + path
+	}
+}
@@ -0,0 +1,48 @@
+package multiagentspec
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidationErrors(t *testing.T) {
+	var errs ValidationErrors
+	if errs.err() != nil {
+		t.Error("empty ValidationErrors should convert to nil error")
+	}
+
+	errs.addf("name", "is required")
+	errs.add("memory", ValidationErrors{{Path: "ttlSeconds", Message: "must be non-negative"}})
+	errs.add("tasks[0]", errors.New("boom"))
+	errs.add("ignored", nil)
+
+	if len(errs) != 3 {
+		t.Fatalf("len(errs) = %d, want 3", len(errs))
+	}
+
+	want := "name: is required; memory.ttlSeconds: must be non-negative; tasks[0]: boom"
+	if got := errs.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	var target ValidationErrors
+	if !errors.As(errs.err(), &target) {
+		t.Error("err() should be a ValidationErrors")
+	}
+}
+
+func TestJoinPath(t *testing.T) {
+	tests := []struct {
+		prefix, path, want string
+	}{
+		{"", "name", "name"},
+		{"agent", "", "agent"},
+		{"steps", "[0]", "steps[0]"},
+		{"memory", "type", "memory.type"},
+	}
+	for _, tt := range tests {
+		if got := joinPath(tt.prefix, tt.path); got != tt.want {
+			t.Errorf("joinPath(%q, %q) = %q, want %q", tt.prefix, tt.path, got, tt.want)
+		}
+	}
+}
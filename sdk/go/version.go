@@ -0,0 +1,265 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DepConstraint is a parsed agent dependency. Dependencies are written as a
+// bare agent name ("research") or as a name with a version constraint
+// ("research@>=1.2.0").
+type DepConstraint struct {
+	// Name is the name of the agent depended on.
+	Name string `json:"name"`
+
+	// Op is the comparison operator (>=, >, <=, <, =), or empty if the
+	// dependency accepts any version.
+	Op string `json:"op,omitempty"`
+
+	// Version is the semantic version compared against.
+	Version string `json:"version,omitempty"`
+}
+
+// constraintOps lists the supported operators, longest first so that
+// prefixes are matched correctly.
+var constraintOps = []string{">=", "<=", ">", "<", "="}
+
+// ParseDepConstraint parses a dependency of the form "name" or
+// "name@<op><version>". A version without an operator means "=".
+func ParseDepConstraint(dep string) (DepConstraint, error) {
+	name, spec, hasSpec := strings.Cut(dep, "@")
+	if name == "" {
+		return DepConstraint{}, fmt.Errorf("dependency %q: name is required", dep)
+	}
+	if !hasSpec {
+		return DepConstraint{Name: name}, nil
+	}
+
+	c := DepConstraint{Name: name, Op: "="}
+	for _, op := range constraintOps {
+		if strings.HasPrefix(spec, op) {
+			c.Op = op
+			spec = spec[len(op):]
+			break
+		}
+	}
+	c.Version = strings.TrimSpace(spec)
+	if _, err := parseSemver(c.Version); err != nil {
+		return DepConstraint{}, fmt.Errorf("dependency %q: %w", dep, err)
+	}
+	return c, nil
+}
+
+// String returns the constraint in dependency notation.
+func (c DepConstraint) String() string {
+	if c.Op == "" {
+		return c.Name
+	}
+	return c.Name + "@" + c.Op + c.Version
+}
+
+// Satisfied reports whether version satisfies the constraint. A constraint
+// without an operator is satisfied by any version.
+func (c DepConstraint) Satisfied(version string) (bool, error) {
+	if c.Op == "" {
+		return true, nil
+	}
+	cmp, err := CompareVersions(version, c.Version)
+	if err != nil {
+		return false, err
+	}
+	switch c.Op {
+	case ">=":
+		return cmp >= 0, nil
+	case ">":
+		return cmp > 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case "<":
+		return cmp < 0, nil
+	case "=":
+		return cmp == 0, nil
+	}
+	return false, fmt.Errorf("unknown operator %q", c.Op)
+}
+
+// DependencyConstraints parses the agent's Dependencies.
+func (a *Agent) DependencyConstraints() ([]DepConstraint, error) {
+	constraints := make([]DepConstraint, 0, len(a.Dependencies))
+	for _, dep := range a.Dependencies {
+		c, err := ParseDepConstraint(dep)
+		if err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, c)
+	}
+	return constraints, nil
+}
+
+// dependencyName returns the agent name of a dependency, without any
+// version constraint.
+func dependencyName(dep string) string {
+	name, _, _ := strings.Cut(dep, "@")
+	return name
+}
+
+// ValidateDependencies checks that every dependency of every registered agent
+// is registered and that its Version satisfies any version constraint. It
+// returns ValidationErrors describing every problem found.
+func (r *AgentRegistry) ValidateDependencies() error {
+	var errs ValidationErrors
+	for _, agent := range r.All() {
+		errs.add(fmt.Sprintf("agents[%s]", agent.QualifiedName()), agent.ValidateDependencies(r))
+	}
+	return errs.err()
+}
+
+// ValidateDependencies checks that every one of the agent's dependencies is
+// registered in registry and that its Version satisfies any version
+// constraint, so a single agent can be checked before it joins a team. It
+// returns ValidationErrors describing every missing or mismatched dependency.
+func (a *Agent) ValidateDependencies(registry *AgentRegistry) error {
+	var errs ValidationErrors
+	for i, dep := range a.Dependencies {
+		path := fmt.Sprintf("dependencies[%d]", i)
+		c, err := ParseDepConstraint(dep)
+		if err != nil {
+			errs.add(path, err)
+			continue
+		}
+		target, ok := registry.Get(c.Name)
+		if !ok {
+			errs.addf(path, "unknown agent %q", c.Name)
+			continue
+		}
+		if c.Op == "" {
+			continue
+		}
+		if target.Version == "" {
+			errs.addf(path, "requires %s but %s has no version", c, c.Name)
+			continue
+		}
+		ok, err = c.Satisfied(target.Version)
+		if err != nil {
+			errs.addf(path, "agent %s: %v", c.Name, err)
+		} else if !ok {
+			errs.addf(path, "requires %s but %s is %s", c, c.Name, target.Version)
+		}
+	}
+	return errs.err()
+}
+
+// semver is a parsed semantic version.
+type semver struct {
+	core       [3]int
+	prerelease []string
+}
+
+// parseSemver parses a semantic version such as "1.2.3", "v1.2.3", or
+// "1.2.3-rc.1+build.5". Build metadata is ignored.
+func parseSemver(s string) (semver, error) {
+	v := strings.TrimPrefix(s, "v")
+	v, _, _ = strings.Cut(v, "+")
+	v, pre, hasPre := strings.Cut(v, "-")
+
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return semver{}, fmt.Errorf("invalid version %q (want MAJOR.MINOR.PATCH)", s)
+	}
+	var sv semver
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return semver{}, fmt.Errorf("invalid version %q (want MAJOR.MINOR.PATCH)", s)
+		}
+		sv.core[i] = n
+	}
+	if hasPre {
+		if pre == "" {
+			return semver{}, fmt.Errorf("invalid version %q: empty pre-release", s)
+		}
+		sv.prerelease = strings.Split(pre, ".")
+	}
+	return sv, nil
+}
+
+// CompareVersions compares two semantic versions, returning -1, 0, or 1 as a
+// is less than, equal to, or greater than b. Pre-release versions sort before
+// the corresponding release, following semver precedence rules.
+func CompareVersions(a, b string) (int, error) {
+	va, err := parseSemver(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := parseSemver(b)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range va.core {
+		if c := compareInts(va.core[i], vb.core[i]); c != 0 {
+			return c, nil
+		}
+	}
+
+	switch {
+	case len(va.prerelease) == 0 && len(vb.prerelease) == 0:
+		return 0, nil
+	case len(va.prerelease) == 0:
+		return 1, nil
+	case len(vb.prerelease) == 0:
+		return -1, nil
+	}
+	for i := 0; i < len(va.prerelease) && i < len(vb.prerelease); i++ {
+		if c := comparePrerelease(va.prerelease[i], vb.prerelease[i]); c != 0 {
+			return c, nil
+		}
+	}
+	return compareInts(len(va.prerelease), len(vb.prerelease)), nil
+}
+
+// NormalizeVersion parses s as a semantic version, accepting a leading "v",
+// and returns its canonical form: no "v" prefix and no leading zeros in the
+// numeric components. Pre-release and build metadata are kept. It returns an
+// error if s is not a valid version.
+func NormalizeVersion(s string) (string, error) {
+	sv, err := parseSemver(s)
+	if err != nil {
+		return "", err
+	}
+	v := fmt.Sprintf("%d.%d.%d", sv.core[0], sv.core[1], sv.core[2])
+	if len(sv.prerelease) > 0 {
+		v += "-" + strings.Join(sv.prerelease, ".")
+	}
+	if _, build, ok := strings.Cut(s, "+"); ok {
+		v += "+" + build
+	}
+	return v, nil
+}
+
+// comparePrerelease compares pre-release identifiers. Numeric identifiers
+// compare numerically and sort before alphanumeric ones.
+func comparePrerelease(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return compareInts(na, nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,174 @@
+package multiagentspec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDepConstraint(t *testing.T) {
+	tests := []struct {
+		dep     string
+		want    DepConstraint
+		wantErr bool
+	}{
+		{dep: "research", want: DepConstraint{Name: "research"}},
+		{dep: "research@>=1.2.0", want: DepConstraint{Name: "research", Op: ">=", Version: "1.2.0"}},
+		{dep: "research@<2.0.0", want: DepConstraint{Name: "research", Op: "<", Version: "2.0.0"}},
+		{dep: "research@1.0.0", want: DepConstraint{Name: "research", Op: "=", Version: "1.0.0"}},
+		{dep: "shared/review@>v1.0.0-rc.1", want: DepConstraint{Name: "shared/review", Op: ">", Version: "v1.0.0-rc.1"}},
+		{dep: "research@>=1.2", wantErr: true},
+		{dep: "@1.0.0", wantErr: true},
+		{dep: "research@", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dep, func(t *testing.T) {
+			got, err := ParseDepConstraint(tt.dep)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDepConstraint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseDepConstraint() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDepConstraintString(t *testing.T) {
+	c := DepConstraint{Name: "research", Op: ">=", Version: "1.2.0"}
+	if got := c.String(); got != "research@>=1.2.0" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := (DepConstraint{Name: "research"}).String(); got != "research" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"v1.2.3", "1.2.3+build.7", 0},
+		{"1.2.3", "1.10.0", -1},
+		{"2.0.0", "1.99.99", 1},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0-rc.10", "1.0.0-rc.2", 1},
+	}
+	for _, tt := range tests {
+		got, err := CompareVersions(tt.a, tt.b)
+		if err != nil {
+			t.Errorf("CompareVersions(%q, %q) error: %v", tt.a, tt.b, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	if _, err := CompareVersions("latest", "1.0.0"); err == nil {
+		t.Error("CompareVersions should reject non-semver input")
+	}
+}
+
+func TestNormalizeVersion(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"1.2.3", "1.2.3"},
+		{"v1.2.3", "1.2.3"},
+		{"v01.002.3", "1.2.3"},
+		{"1.0.0-rc.1+build.7", "1.0.0-rc.1+build.7"},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeVersion(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("NormalizeVersion(%q) = %q, %v, want %q", tt.in, got, err, tt.want)
+		}
+	}
+	for _, in := range []string{"", "latest", "1.2", "1.2.3-"} {
+		if _, err := NormalizeVersion(in); err == nil {
+			t.Errorf("NormalizeVersion(%q) should fail", in)
+		}
+	}
+}
+
+func TestAgentDependencyConstraints(t *testing.T) {
+	agent := NewAgent("verify", "")
+	agent.Dependencies = []string{"research", "synthesis@>=1.2.0"}
+
+	constraints, err := agent.DependencyConstraints()
+	if err != nil {
+		t.Fatalf("DependencyConstraints failed: %v", err)
+	}
+	if len(constraints) != 2 || constraints[1].Version != "1.2.0" {
+		t.Errorf("constraints = %+v", constraints)
+	}
+
+	agent.Dependencies = append(agent.Dependencies, "bad@>=x")
+	if _, err := agent.DependencyConstraints(); err == nil {
+		t.Error("DependencyConstraints should fail for an invalid version")
+	}
+	if err := agent.Validate(); err == nil || !strings.Contains(err.Error(), "dependencies[2]:") {
+		t.Errorf("Validate() = %v, want dependencies error", err)
+	}
+}
+
+func TestAgentRegistryValidateDependencies(t *testing.T) {
+	research := NewAgent("research", "")
+	research.Version = "1.1.0"
+	synthesis := NewAgent("synthesis", "")
+	verify := NewAgent("verify", "")
+	verify.Dependencies = []string{"research@>=1.2.0", "synthesis@>=1.0.0", "ghost", "synthesis"}
+	registry := newTestRegistry(t, research, synthesis, verify)
+
+	err := registry.ValidateDependencies()
+	if err == nil {
+		t.Fatal("ValidateDependencies() = nil, want error")
+	}
+	for _, want := range []string{
+		"agents[verify].dependencies[0]: requires research@>=1.2.0 but research is 1.1.0",
+		"agents[verify].dependencies[1]: requires synthesis@>=1.0.0 but synthesis has no version",
+		`agents[verify].dependencies[2]: unknown agent "ghost"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("ValidateDependencies() = %q, want it to contain %q", err, want)
+		}
+	}
+
+	research.Version = "1.2.0"
+	synthesis.Version = "1.0.0"
+	verify.Dependencies = verify.Dependencies[:2]
+	if err := registry.ValidateDependencies(); err != nil {
+		t.Errorf("ValidateDependencies() = %v, want nil", err)
+	}
+}
+
+func TestAgentValidateDependencies(t *testing.T) {
+	registry := NewAgentRegistry()
+	research := NewAgent("research", "")
+	research.Version = "1.1.0"
+	if err := registry.Register(research); err != nil {
+		t.Fatal(err)
+	}
+
+	// The agent under review is not registered itself.
+	draft := NewAgent("draft", "")
+	draft.Dependencies = []string{"research", "research@>=1.2.0", "ghost"}
+	err := draft.ValidateDependencies(registry)
+	want := `dependencies[1]: requires research@>=1.2.0 but research is 1.1.0; dependencies[2]: unknown agent "ghost"`
+	if err == nil || err.Error() != want {
+		t.Errorf("ValidateDependencies() = %v, want %q", err, want)
+	}
+
+	draft.Dependencies = []string{"research@>=1.0.0"}
+	if err := draft.ValidateDependencies(registry); err != nil {
+		t.Errorf("ValidateDependencies() = %v, want nil", err)
+	}
+	if err := draft.ValidateDependencies(nil); err == nil {
+		t.Error("ValidateDependencies(nil) should report the dependency as unknown")
+	}
+}
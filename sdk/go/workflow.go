@@ -0,0 +1,847 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TopologicalOrder returns the steps ordered so that every step appears after
+// the steps it depends on. Whenever several steps are ready, the one declared
+// first in Steps comes first. It returns an error if a step depends on an
+// unknown step or the dependencies contain a cycle.
+func (w *Workflow) TopologicalOrder() ([]Step, error) {
+	return w.topologicalOrder(nil)
+}
+
+// topologicalOrder implements TopologicalOrder. If pick is non-nil, it
+// chooses the index of the next step to schedule from the ready queue (which
+// is in declaration order) given the previously scheduled step, or nil for
+// the first step.
+func (w *Workflow) topologicalOrder(pick func(ready []*Step, last *Step) int) ([]Step, error) {
+	steps := w.stepsByName()
+	inDegree := make(map[string]int, len(w.Steps))
+	downstream := make(map[string][]string)
+
+	for _, step := range w.Steps {
+		inDegree[step.Name] += 0
+		for _, dep := range step.DependsOn {
+			if _, ok := steps[dep]; !ok {
+				return nil, fmt.Errorf("step %s depends on unknown step %s", step.Name, dep)
+			}
+			inDegree[step.Name]++
+			downstream[dep] = append(downstream[dep], step.Name)
+		}
+	}
+
+	position := make(map[string]int, len(w.Steps))
+	var ready []string
+	for i, step := range w.Steps {
+		position[step.Name] = i
+		if inDegree[step.Name] == 0 {
+			ready = append(ready, step.Name)
+		}
+	}
+
+	order := make([]Step, 0, len(w.Steps))
+	var last *Step
+	for len(ready) > 0 {
+		i := 0
+		if pick != nil {
+			candidates := make([]*Step, len(ready))
+			for j, name := range ready {
+				candidates[j] = steps[name]
+			}
+			i = pick(candidates, last)
+		}
+		name := ready[i]
+		ready = append(ready[:i], ready[i+1:]...)
+		last = steps[name]
+		order = append(order, *last)
+
+		for _, next := range downstream[name] {
+			inDegree[next]--
+			if inDegree[next] == 0 {
+				ready = insertByPosition(ready, next, position)
+			}
+		}
+	}
+
+	if len(order) < len(w.Steps) {
+		var cyclic []string
+		for _, step := range w.Steps {
+			if inDegree[step.Name] > 0 {
+				cyclic = append(cyclic, step.Name)
+			}
+		}
+		return nil, fmt.Errorf("workflow has a dependency cycle among steps: %s", strings.Join(cyclic, ", "))
+	}
+	return order, nil
+}
+
+// GroupConsecutiveByAgent returns the steps in a dependency-respecting order,
+// split into batches of consecutive steps that use the same agent. When
+// several steps are ready to run, a step using the same agent as the previous
+// step is preferred, so that batches are as long as possible. Running the
+// batches in order, and the steps of each batch in order, never starts a step
+// before its dependencies. It returns nil if the workflow's dependencies are
+// invalid (see TopologicalOrder).
+func (w *Workflow) GroupConsecutiveByAgent() [][]Step {
+	order, err := w.topologicalOrder(func(ready []*Step, last *Step) int {
+		if last != nil {
+			for i, step := range ready {
+				if step.Agent == last.Agent {
+					return i
+				}
+			}
+		}
+		return 0
+	})
+	if err != nil {
+		return nil
+	}
+
+	var groups [][]Step
+	for _, step := range order {
+		if n := len(groups); n > 0 && groups[n-1][0].Agent == step.Agent {
+			groups[n-1] = append(groups[n-1], step)
+			continue
+		}
+		groups = append(groups, []Step{step})
+	}
+	return groups
+}
+
+// insertByPosition inserts name into the sorted queue, keeping the queue
+// ordered by each step's declaration position.
+func insertByPosition(queue []string, name string, position map[string]int) []string {
+	i := len(queue)
+	for i > 0 && position[queue[i-1]] > position[name] {
+		i--
+	}
+	queue = append(queue, "")
+	copy(queue[i+1:], queue[i:])
+	queue[i] = name
+	return queue
+}
+
+// Stages groups the steps into execution stages: stage 0 holds steps with no
+// dependencies, and each later stage holds steps whose dependencies all lie in
+// earlier stages, with at least one in the stage immediately before. Steps in
+// the same stage may run concurrently. Within a stage, steps keep declaration
+// order. It returns an error under the same conditions as TopologicalOrder.
+func (w *Workflow) Stages() ([][]Step, error) {
+	order, err := w.TopologicalOrder()
+	if err != nil {
+		return nil, err
+	}
+
+	level := make(map[string]int, len(order))
+	var stages [][]Step
+	for _, step := range order {
+		l := 0
+		for _, dep := range step.DependsOn {
+			if level[dep]+1 > l {
+				l = level[dep] + 1
+			}
+		}
+		level[step.Name] = l
+		for len(stages) <= l {
+			stages = append(stages, nil)
+		}
+	}
+	for _, step := range w.Steps {
+		stages[level[step.Name]] = append(stages[level[step.Name]], step)
+	}
+	return stages, nil
+}
+
+// Conflict reports steps in the same parallel stage that declare a file
+// output with the same name, and would race writing it.
+type Conflict struct {
+	// Stage is the index of the stage (see Workflow.Stages).
+	Stage int `json:"stage"`
+
+	// Output is the shared output name.
+	Output string `json:"output"`
+
+	// Steps are the conflicting steps, in declaration order.
+	Steps []string `json:"steps"`
+}
+
+// DetectOutputConflicts reports file-typed outputs (PortTypeFile) declared
+// under the same name by more than one step of the same stage. Sequential
+// workflows run one step at a time and never conflict. It returns nil if the
+// workflow's dependencies are invalid (see TopologicalOrder).
+func (w *Workflow) DetectOutputConflicts() []Conflict {
+	if w.Type == WorkflowSequential {
+		return nil
+	}
+	stages, err := w.Stages()
+	if err != nil {
+		return nil
+	}
+
+	var conflicts []Conflict
+	for i, stage := range stages {
+		writers := make(map[string][]string)
+		var names []string
+		for _, step := range stage {
+			for _, out := range step.Outputs {
+				if out.Type != PortTypeFile {
+					continue
+				}
+				if _, ok := writers[out.Name]; !ok {
+					names = append(names, out.Name)
+				}
+				writers[out.Name] = append(writers[out.Name], step.Name)
+			}
+		}
+		for _, name := range names {
+			if len(writers[name]) > 1 {
+				conflicts = append(conflicts, Conflict{Stage: i, Output: name, Steps: writers[name]})
+			}
+		}
+	}
+	return conflicts
+}
+
+// appendPrefix is prepended to appended step names that collide with
+// existing steps.
+const appendPrefix = "sub-"
+
+// Append returns a new workflow with the steps of other added after w's
+// steps, with w's Type and Budget. Appended steps whose names collide with a
+// step in w are renamed with the prefix "sub-", and their DependsOn and input
+// From references are rewritten to match. Root steps of other (those without dependencies inside
+// other) are made to depend on every step named in connectAfter, which must
+// exist in w. Neither w nor other is modified. It returns an error if other
+// is nil, the prefixed name still collides, or connectAfter names an unknown
+// step.
+func (w *Workflow) Append(other *Workflow, connectAfter []string) (*Workflow, error) {
+	if other == nil {
+		return nil, fmt.Errorf("append workflow: workflow to append is nil")
+	}
+	existing := w.stepsByName()
+	for _, name := range connectAfter {
+		if _, ok := existing[name]; !ok {
+			return nil, fmt.Errorf("append workflow: connectAfter references unknown step %s", name)
+		}
+	}
+
+	internal := other.stepsByName()
+	renamed := make(map[string]string, len(other.Steps))
+	taken := make(map[string]bool, len(w.Steps)+len(other.Steps))
+	for name := range existing {
+		taken[name] = true
+	}
+	for _, step := range other.Steps {
+		if _, clash := existing[step.Name]; !clash {
+			taken[step.Name] = true
+		}
+	}
+	for _, step := range other.Steps {
+		name := step.Name
+		if _, clash := existing[name]; clash {
+			name = appendPrefix + name
+			if taken[name] {
+				return nil, fmt.Errorf("append workflow: step %s collides even as %s", step.Name, name)
+			}
+			taken[name] = true
+		}
+		renamed[step.Name] = name
+	}
+
+	merged := &Workflow{
+		Type:   w.Type,
+		Budget: w.Budget,
+		Steps:  append(make([]Step, 0, len(w.Steps)+len(other.Steps)), w.Steps...),
+	}
+	for _, step := range other.Steps {
+		appended := step
+		appended.Name = renamed[step.Name]
+
+		appended.DependsOn = nil
+		isRoot := true
+		for _, dep := range step.DependsOn {
+			if _, ok := internal[dep]; ok {
+				isRoot = false
+				dep = renamed[dep]
+			}
+			appended.DependsOn = append(appended.DependsOn, dep)
+		}
+		if isRoot {
+			appended.DependsOn = append(appended.DependsOn, connectAfter...)
+		}
+
+		appended.Inputs = append([]Port(nil), step.Inputs...)
+		for i, in := range appended.Inputs {
+			src, out, ok := parsePortRef(in.From)
+			if _, internalRef := internal[src]; ok && internalRef {
+				appended.Inputs[i].From = renamed[src] + "." + out
+			}
+		}
+		appended.Outputs = append([]Port(nil), step.Outputs...)
+		merged.Steps = append(merged.Steps, appended)
+	}
+	return merged, nil
+}
+
+// Subgraph returns a new workflow containing only the named steps, in
+// declaration order, with w's Type and Budget. A DependsOn reference to a step
+// outside the set is an error unless dropExternal is true, in which case the
+// reference is removed. Inputs wired with From to a step outside the set
+// become external inputs with no From, to be supplied by the caller. w is not
+// modified. It returns an error if a name is not a step of w.
+func (w *Workflow) Subgraph(stepNames []string, dropExternal bool) (*Workflow, error) {
+	steps := w.stepsByName()
+	keep := make(map[string]bool, len(stepNames))
+	for _, name := range stepNames {
+		if _, ok := steps[name]; !ok {
+			return nil, fmt.Errorf("subgraph: unknown step %s", name)
+		}
+		keep[name] = true
+	}
+
+	sub := &Workflow{Type: w.Type, Budget: w.Budget}
+	for _, step := range w.Steps {
+		if !keep[step.Name] {
+			continue
+		}
+		extracted := step
+		extracted.DependsOn = nil
+		for _, dep := range step.DependsOn {
+			if !keep[dep] {
+				if !dropExternal {
+					return nil, fmt.Errorf("subgraph: step %s depends on %s, which is not in the subgraph", step.Name, dep)
+				}
+				continue
+			}
+			extracted.DependsOn = append(extracted.DependsOn, dep)
+		}
+		extracted.Inputs = append([]Port(nil), step.Inputs...)
+		for i, in := range extracted.Inputs {
+			if src, _, ok := parsePortRef(in.From); ok && !keep[src] {
+				extracted.Inputs[i].From = ""
+			}
+		}
+		extracted.Outputs = append([]Port(nil), step.Outputs...)
+		sub.Steps = append(sub.Steps, extracted)
+	}
+	return sub, nil
+}
+
+// ValidateInputProduction checks that every step input wired with From
+// ("step.output") references a step that exists, that the consuming step
+// depends on (directly or transitively), and that actually declares the
+// named output. It returns ValidationErrors describing every broken input.
+func (w *Workflow) ValidateInputProduction() error {
+	steps := w.stepsByName()
+
+	var errs ValidationErrors
+	for i, step := range w.Steps {
+		var upstream map[string]bool
+		for j, in := range step.Inputs {
+			if in.From == "" {
+				continue
+			}
+			path := fmt.Sprintf("steps[%d].inputs[%d]", i, j)
+			src, out, ok := parsePortRef(in.From)
+			if !ok {
+				errs.addf(path, "malformed from %q (want step.output)", in.From)
+				continue
+			}
+			producer, ok := steps[src]
+			if !ok {
+				errs.addf(path, "references unknown step %s", src)
+				continue
+			}
+			if upstream == nil {
+				upstream = w.upstream(step.Name)
+			}
+			if !upstream[src] {
+				errs.addf(path, "references %s, but step %s does not depend on %s", in.From, step.Name, src)
+			}
+			if !hasPort(producer.Outputs, out) {
+				errs.addf(path, "references %s, which is not an output of %s", in.From, src)
+			}
+		}
+	}
+	return errs.err()
+}
+
+// ValidatePortTypes checks that every step input wired with From has the same
+// Type as the output it references. Inputs or outputs without a Type, and
+// references that do not resolve (see ValidateInputProduction), are skipped.
+// It returns ValidationErrors describing every mismatched edge.
+func (w *Workflow) ValidatePortTypes() error {
+	steps := w.stepsByName()
+
+	var errs ValidationErrors
+	for i, step := range w.Steps {
+		for j, in := range step.Inputs {
+			src, out, ok := parsePortRef(in.From)
+			if !ok || in.Type == "" {
+				continue
+			}
+			producer, ok := steps[src]
+			if !ok {
+				continue
+			}
+			for _, o := range producer.Outputs {
+				if o.Name == out && o.Type != "" && o.Type != in.Type {
+					errs.addf(fmt.Sprintf("steps[%d].inputs[%d]", i, j), "type %s does not match type %s of %s", in.Type, o.Type, in.From)
+				}
+			}
+		}
+	}
+	return errs.err()
+}
+
+// ValidateSequentialOrder checks that, in a sequential workflow, every step
+// depends only on steps listed before it, since Steps order is the execution
+// order. Other workflow types are not checked. It returns ValidationErrors
+// describing every forward or unknown reference.
+func (w *Workflow) ValidateSequentialOrder() error {
+	if w.Type != WorkflowSequential {
+		return nil
+	}
+
+	position := make(map[string]int, len(w.Steps))
+	for i, step := range w.Steps {
+		if _, ok := position[step.Name]; !ok {
+			position[step.Name] = i
+		}
+	}
+
+	var errs ValidationErrors
+	for i, step := range w.Steps {
+		path := fmt.Sprintf("steps[%d]", i)
+		for _, dep := range step.DependsOn {
+			at, ok := position[dep]
+			switch {
+			case !ok:
+				errs.addf(path, "depends on unknown step %s", dep)
+			case at >= i:
+				errs.addf(path, "depends on %s, which runs later in a sequential workflow", dep)
+			}
+		}
+	}
+	return errs.err()
+}
+
+// ValidateParallelIndependence checks that no step of a parallel workflow
+// declares depends_on, since parallel steps are meant to be independent.
+// Other workflow types are not checked. It returns ValidationErrors naming
+// every step with dependencies.
+func (w *Workflow) ValidateParallelIndependence() error {
+	if w.Type != WorkflowParallel {
+		return nil
+	}
+	var errs ValidationErrors
+	for i, step := range w.Steps {
+		if len(step.DependsOn) > 0 {
+			errs.addf(fmt.Sprintf("steps[%d]", i), "depends on %s, but parallel steps must be independent; use type %s for dependencies",
+				strings.Join(step.DependsOn, ", "), WorkflowDAG)
+		}
+	}
+	return errs.err()
+}
+
+// IsDeterministic reports whether every step is explicitly marked
+// Deterministic, meaning the outputs of a previous run can be reused for the
+// same inputs. Steps that leave Deterministic unset are treated as
+// non-deterministic.
+func (w *Workflow) IsDeterministic() bool {
+	for _, step := range w.Steps {
+		if step.Deterministic == nil || !*step.Deterministic {
+			return false
+		}
+	}
+	return true
+}
+
+// DetectDataDeadlocks finds cycles in the graph formed by input From
+// references ("step.output") together with DependsOn edges. A data cycle
+// deadlocks at runtime because each step waits for another's output, even
+// when DependsOn alone is acyclic. Each cycle is reported once as
+// "a -> b -> a", starting from its earliest declared step. References to
+// unknown steps are ignored.
+func (w *Workflow) DetectDataDeadlocks() []string {
+	steps := w.stepsByName()
+	edges := make(map[string][]string, len(w.Steps))
+	for _, step := range w.Steps {
+		seen := make(map[string]bool)
+		addEdge := func(to string) {
+			if _, ok := steps[to]; ok && !seen[to] {
+				seen[to] = true
+				edges[step.Name] = append(edges[step.Name], to)
+			}
+		}
+		for _, in := range step.Inputs {
+			if src, _, ok := parsePortRef(in.From); ok {
+				addEdge(src)
+			}
+		}
+		for _, dep := range step.DependsOn {
+			addEdge(dep)
+		}
+	}
+
+	const (
+		unvisited = iota
+		active
+		done
+	)
+	position := make(map[string]int, len(w.Steps))
+	for i, step := range w.Steps {
+		if _, ok := position[step.Name]; !ok {
+			position[step.Name] = i
+		}
+	}
+	state := make(map[string]int, len(w.Steps))
+	reported := make(map[string]bool)
+	var stack []string
+	var cycles []string
+
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = active
+		stack = append(stack, name)
+		for _, next := range edges[name] {
+			switch state[next] {
+			case unvisited:
+				visit(next)
+			case active:
+				start := len(stack) - 1
+				for stack[start] != next {
+					start--
+				}
+				// Rotate the cycle to start at its earliest declared step.
+				cycle := stack[start:]
+				first := 0
+				for i, member := range cycle {
+					if position[member] < position[cycle[first]] {
+						first = i
+					}
+				}
+				cycle = append(append([]string(nil), cycle[first:]...), cycle[:first]...)
+				members := append([]string(nil), cycle...)
+				sort.Strings(members)
+				if key := strings.Join(members, "\x00"); !reported[key] {
+					reported[key] = true
+					cycles = append(cycles, strings.Join(append(cycle, cycle[0]), " -> "))
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = done
+	}
+
+	for _, step := range w.Steps {
+		if state[step.Name] == unvisited {
+			visit(step.Name)
+		}
+	}
+	return cycles
+}
+
+// DuplicateOutputNames returns output names declared by more than one step,
+// mapped to the producing steps in declaration order. Duplicates are legal
+// because inputs reference outputs as "step.output", but they are a common
+// source of wiring confusion in large workflows.
+func (w *Workflow) DuplicateOutputNames() map[string][]string {
+	producers := make(map[string][]string)
+	for _, step := range w.Steps {
+		for _, out := range step.Outputs {
+			producers[out.Name] = append(producers[out.Name], step.Name)
+		}
+	}
+
+	dups := make(map[string][]string)
+	for name, steps := range producers {
+		if len(steps) > 1 {
+			dups[name] = steps
+		}
+	}
+	return dups
+}
+
+// StepPort is a port together with the name of the step that declares it.
+type StepPort struct {
+	Step string `json:"step"`
+	Port Port   `json:"port"`
+}
+
+// UnusedOutputs returns the step outputs that no input references with From,
+// in declaration order. Outputs of terminal steps, which no other step depends
+// on or reads from, are usually the workflow's results and are left out
+// unless includeTerminal is true.
+func (w *Workflow) UnusedOutputs(includeTerminal bool) []StepPort {
+	consumed := make(map[string]bool)
+	downstream := make(map[string]bool)
+	for _, step := range w.Steps {
+		for _, dep := range step.DependsOn {
+			downstream[dep] = true
+		}
+		for _, in := range step.Inputs {
+			if src, _, ok := parsePortRef(in.From); ok {
+				consumed[in.From] = true
+				downstream[src] = true
+			}
+		}
+	}
+
+	var unused []StepPort
+	for _, step := range w.Steps {
+		if !includeTerminal && !downstream[step.Name] {
+			continue
+		}
+		for _, out := range step.Outputs {
+			if !consumed[step.Name+"."+out.Name] {
+				unused = append(unused, StepPort{Step: step.Name, Port: out})
+			}
+		}
+	}
+	return unused
+}
+
+// UnreachableSteps returns the steps that forward traversal from the roots
+// (steps without depends_on) never reaches, in declaration order. A step is
+// reached once all of its dependencies have been, so a step that depends on an
+// unknown step, lies on a dependency cycle, or depends on such a step can
+// never execute. It returns an error if step names are not unique, since
+// dependencies would be ambiguous.
+func (w *Workflow) UnreachableSteps() ([]string, error) {
+	if dups := w.DuplicateStepNames(); len(dups) > 0 {
+		return nil, fmt.Errorf("duplicate step names: %s", strings.Join(dups, ", "))
+	}
+
+	steps := w.stepsByName()
+	pending := make(map[string]int, len(w.Steps))
+	downstream := make(map[string][]string)
+	var queue []string
+	for _, step := range w.Steps {
+		for _, dep := range step.DependsOn {
+			pending[step.Name]++
+			if _, ok := steps[dep]; ok {
+				downstream[dep] = append(downstream[dep], step.Name)
+			}
+		}
+		if pending[step.Name] == 0 {
+			queue = append(queue, step.Name)
+		}
+	}
+
+	reached := make(map[string]bool, len(w.Steps))
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		reached[name] = true
+		for _, next := range downstream[name] {
+			pending[next]--
+			if pending[next] == 0 {
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	var unreachable []string
+	for _, step := range w.Steps {
+		if !reached[step.Name] {
+			unreachable = append(unreachable, step.Name)
+		}
+	}
+	return unreachable, nil
+}
+
+// DuplicateStepNames returns the step names declared more than once, in the
+// order their first duplicate appears. Unnamed steps are ignored.
+func (w *Workflow) DuplicateStepNames() []string {
+	seen := make(map[string]int, len(w.Steps))
+	var dups []string
+	for _, step := range w.Steps {
+		if step.Name == "" {
+			continue
+		}
+		seen[step.Name]++
+		if seen[step.Name] == 2 {
+			dups = append(dups, step.Name)
+		}
+	}
+	return dups
+}
+
+// Validate checks the workflow's budget, that every step has a unique name
+// and an agent, and its steps for malformed port transforms and resource
+// limits. It returns ValidationErrors describing every problem found.
+func (w *Workflow) Validate() error {
+	var errs ValidationErrors
+	if w.Budget != nil {
+		errs.add("budget", w.Budget.Validate())
+	}
+	seen := make(map[string]bool, len(w.Steps))
+	for i, step := range w.Steps {
+		switch {
+		case step.Name == "":
+			errs.addf(fmt.Sprintf("steps[%d].name", i), "is required")
+		case seen[step.Name]:
+			errs.addf(fmt.Sprintf("steps[%d].name", i), "duplicate step name %q", step.Name)
+		}
+		seen[step.Name] = true
+		if step.Agent == "" {
+			errs.addf(fmt.Sprintf("steps[%d].agent", i), "step %q has no agent", step.Name)
+		}
+	}
+	for i, step := range w.Steps {
+		path := fmt.Sprintf("steps[%d]", i)
+		for _, ports := range [][]Port{step.Inputs, step.Outputs} {
+			for i := range ports {
+				errs.add(path, ports[i].ValidateTransform())
+			}
+		}
+		if step.Resources != nil {
+			errs.add(path+".resources", step.Resources.Validate())
+		}
+	}
+	return errs.err()
+}
+
+// ValidateAll runs the full suite of workflow checks: Validate, that every
+// depends_on names a known step, that the dependencies are acyclic,
+// ValidateInputProduction, and ValidatePortTypes. It returns the aggregated
+// ValidationErrors. The cycle check is skipped while depends_on references
+// are unresolved.
+func (w *Workflow) ValidateAll() error {
+	var errs ValidationErrors
+	errs.add("", w.Validate())
+
+	steps := w.stepsByName()
+	resolved := true
+	for i, step := range w.Steps {
+		for j, dep := range step.DependsOn {
+			if _, ok := steps[dep]; !ok {
+				errs.addf(fmt.Sprintf("steps[%d].depends_on[%d]", i, j), "unknown step %s", dep)
+				resolved = false
+			}
+		}
+	}
+	if resolved {
+		if _, err := w.TopologicalOrder(); err != nil {
+			errs.addf("steps", "%v", err)
+		}
+	}
+
+	errs.add("", w.ValidateInputProduction())
+	errs.add("", w.ValidatePortTypes())
+	return errs.err()
+}
+
+// ExceedsStepBudget reports whether the workflow declares more steps than
+// Budget.MaxSteps allows. It returns false when no step budget is set.
+func (w *Workflow) ExceedsStepBudget() bool {
+	if w.Budget == nil || w.Budget.MaxSteps == 0 {
+		return false
+	}
+	return len(w.Steps) > w.Budget.MaxSteps
+}
+
+// Tree renders the workflow's dependency structure as an indented text tree.
+// Roots are steps without depends_on, and each step's children are the steps
+// that depend on it, both in declaration order. A step with several
+// dependencies is expanded under the first one reached and shown elsewhere
+// as "name (see above)". Steps unreachable from any root, such as members of
+// a dependency cycle, are rendered as additional roots.
+func (w *Workflow) Tree() string {
+	children := make(map[string][]string)
+	for _, step := range w.Steps {
+		for _, dep := range step.DependsOn {
+			children[dep] = append(children[dep], step.Name)
+		}
+	}
+
+	var b strings.Builder
+	shown := make(map[string]bool, len(w.Steps))
+	var render func(name, prefix, branch, indent string)
+	render = func(name, prefix, branch, indent string) {
+		if shown[name] {
+			b.WriteString(prefix + branch + name + " (see above)\n")
+			return
+		}
+		shown[name] = true
+		b.WriteString(prefix + branch + name + "\n")
+		kids := children[name]
+		for i, kid := range kids {
+			if i == len(kids)-1 {
+				render(kid, prefix+indent, "└── ", "    ")
+			} else {
+				render(kid, prefix+indent, "├── ", "│   ")
+			}
+		}
+	}
+
+	for _, step := range w.Steps {
+		if len(step.DependsOn) == 0 && !shown[step.Name] {
+			render(step.Name, "", "", "")
+		}
+	}
+	for _, step := range w.Steps {
+		if !shown[step.Name] {
+			render(step.Name, "", "", "")
+		}
+	}
+	return b.String()
+}
+
+// parsePortRef splits a port reference of the form "step.output".
+func parsePortRef(ref string) (step, output string, ok bool) {
+	step, output, ok = strings.Cut(ref, ".")
+	if !ok || step == "" || output == "" {
+		return "", "", false
+	}
+	return step, output, true
+}
+
+// hasPort reports whether ports contains a port with the given name.
+func hasPort(ports []Port, name string) bool {
+	for _, p := range ports {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// stepsByName indexes the workflow's steps by name.
+func (w *Workflow) stepsByName() map[string]*Step {
+	steps := make(map[string]*Step, len(w.Steps))
+	for i := range w.Steps {
+		steps[w.Steps[i].Name] = &w.Steps[i]
+	}
+	return steps
+}
+
+// upstream returns the names of all steps that the named step depends on,
+// directly or transitively. Unknown step names are ignored.
+func (w *Workflow) upstream(name string) map[string]bool {
+	steps := w.stepsByName()
+	seen := make(map[string]bool)
+
+	var visit func(string)
+	visit = func(n string) {
+		step, ok := steps[n]
+		if !ok {
+			return
+		}
+		for _, dep := range step.DependsOn {
+			if seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			visit(dep)
+		}
+	}
+	visit(name)
+
+	return seen
+}
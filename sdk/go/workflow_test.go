@@ -0,0 +1,680 @@
+package multiagentspec
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateInputProduction(t *testing.T) {
+	w := &Workflow{
+		Type: WorkflowDAG,
+		Steps: []Step{
+			{
+				Name:    "results",
+				Agent:   "a",
+				Outputs: []Port{{Name: "summary"}},
+			},
+			{
+				Name:      "research",
+				Agent:     "b",
+				DependsOn: []string{"results"},
+				Inputs: []Port{
+					{Name: "summary", From: "results.summary"},
+					{Name: "topic", From: "results.version"},
+					{Name: "manual"},
+				},
+			},
+			{
+				Name:  "report",
+				Agent: "c",
+				Inputs: []Port{
+					{Name: "data", From: "results.summary"},
+					{Name: "other", From: "ghost.out"},
+					{Name: "bad", From: "nodot"},
+				},
+			},
+		},
+	}
+
+	err := w.ValidateInputProduction()
+	if err == nil {
+		t.Fatal("ValidateInputProduction() = nil, want error")
+	}
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("error type = %T, want ValidationErrors", err)
+	}
+
+	want := []string{
+		"steps[1].inputs[1]: references results.version, which is not an output of results",
+		"steps[2].inputs[0]: references results.summary, but step report does not depend on results",
+		"steps[2].inputs[1]: references unknown step ghost",
+		`steps[2].inputs[2]: malformed from "nodot" (want step.output)`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("len(errs) = %d, want %d: %v", len(errs), len(want), errs)
+	}
+	for i, msg := range want {
+		if errs[i].Error() != msg {
+			t.Errorf("errs[%d] = %q, want %q", i, errs[i].Error(), msg)
+		}
+	}
+}
+
+func TestValidateInputProductionTransitive(t *testing.T) {
+	w := &Workflow{
+		Steps: []Step{
+			{Name: "a", Agent: "x", Outputs: []Port{{Name: "out"}}},
+			{Name: "b", Agent: "y", DependsOn: []string{"a"}},
+			{Name: "c", Agent: "z", DependsOn: []string{"b"}, Inputs: []Port{{Name: "in", From: "a.out"}}},
+		},
+	}
+	if err := w.ValidateInputProduction(); err != nil {
+		t.Errorf("ValidateInputProduction() = %v, want nil", err)
+	}
+}
+
+func TestParsePortRef(t *testing.T) {
+	step, out, ok := parsePortRef("research.candidate_urls")
+	if !ok || step != "research" || out != "candidate_urls" {
+		t.Errorf("parsePortRef = (%q, %q, %v)", step, out, ok)
+	}
+	for _, ref := range []string{"", "research", ".out", "step."} {
+		if _, _, ok := parsePortRef(ref); ok {
+			t.Errorf("parsePortRef(%q) should fail", ref)
+		}
+	}
+}
+
+func TestDuplicateOutputNames(t *testing.T) {
+	w := &Workflow{
+		Steps: []Step{
+			{Name: "a", Agent: "x", Outputs: []Port{{Name: "results"}, {Name: "log"}}},
+			{Name: "b", Agent: "y", Outputs: []Port{{Name: "results"}}},
+			{Name: "c", Agent: "z", Outputs: []Port{{Name: "summary"}, {Name: "results"}}},
+		},
+	}
+
+	dups := w.DuplicateOutputNames()
+	if len(dups) != 1 {
+		t.Fatalf("len(dups) = %d, want 1: %v", len(dups), dups)
+	}
+	got := dups["results"]
+	if len(got) != 3 || got[0] != "a" || got[1] != "b" || got[2] != "c" {
+		t.Errorf("dups[results] = %v, want [a b c]", got)
+	}
+
+	if dups := (&Workflow{}).DuplicateOutputNames(); len(dups) != 0 {
+		t.Errorf("empty workflow dups = %v", dups)
+	}
+}
+
+func TestTopologicalOrder(t *testing.T) {
+	w := &Workflow{
+		Steps: []Step{
+			{Name: "report", Agent: "a", DependsOn: []string{"analyze", "fetch"}},
+			{Name: "fetch", Agent: "a"},
+			{Name: "analyze", Agent: "a", DependsOn: []string{"fetch"}},
+			{Name: "notify", Agent: "a"},
+		},
+	}
+
+	order, err := w.TopologicalOrder()
+	if err != nil {
+		t.Fatalf("TopologicalOrder failed: %v", err)
+	}
+	var names []string
+	for _, s := range order {
+		names = append(names, s.Name)
+	}
+	if got := strings.Join(names, ","); got != "fetch,analyze,report,notify" {
+		t.Errorf("order = %s, want fetch,analyze,report,notify", got)
+	}
+}
+
+func TestTopologicalOrderErrors(t *testing.T) {
+	cyclic := &Workflow{
+		Steps: []Step{
+			{Name: "a", Agent: "x", DependsOn: []string{"b"}},
+			{Name: "b", Agent: "x", DependsOn: []string{"a"}},
+			{Name: "c", Agent: "x"},
+		},
+	}
+	_, err := cyclic.TopologicalOrder()
+	if err == nil || !strings.Contains(err.Error(), "cycle among steps: a, b") {
+		t.Errorf("TopologicalOrder error = %v, want cycle error", err)
+	}
+
+	unknown := &Workflow{Steps: []Step{{Name: "a", Agent: "x", DependsOn: []string{"ghost"}}}}
+	_, err = unknown.TopologicalOrder()
+	if err == nil || !strings.Contains(err.Error(), "unknown step ghost") {
+		t.Errorf("TopologicalOrder error = %v, want unknown step error", err)
+	}
+}
+
+func TestWorkflowValidate(t *testing.T) {
+	w := &Workflow{
+		Steps: []Step{
+			{Name: "analyze", Agent: "a", Resources: &ResourceLimits{Memory: "8Gi"}},
+			{Name: "format", Agent: "a", Resources: &ResourceLimits{CPU: "lots"}},
+			{Name: "pick", Agent: "a", Outputs: []Port{{Name: "x", Transform: "items"}}},
+		},
+	}
+
+	err := w.Validate()
+	if err == nil {
+		t.Fatal("Validate() = nil, want error")
+	}
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("Validate() = %v, want 2 errors", err)
+	}
+	if errs[0].Path != "steps[1].resources.cpu" {
+		t.Errorf("errs[0].Path = %q", errs[0].Path)
+	}
+	if errs[1].Path != "steps[2]" {
+		t.Errorf("errs[1].Path = %q", errs[1].Path)
+	}
+}
+
+func TestWorkflowUnusedOutputs(t *testing.T) {
+	w := &Workflow{Steps: []Step{
+		{Name: "fetch", Agent: "x", Outputs: []Port{{Name: "data"}, {Name: "log"}}},
+		{Name: "analyze", Agent: "x", DependsOn: []string{"fetch"},
+			Inputs:  []Port{{Name: "in", From: "fetch.data"}},
+			Outputs: []Port{{Name: "stats"}}},
+		{Name: "notify", Agent: "x", Outputs: []Port{{Name: "receipt"}}},
+	}}
+
+	format := func(ports []StepPort) string {
+		var names []string
+		for _, sp := range ports {
+			names = append(names, sp.Step+"."+sp.Port.Name)
+		}
+		return strings.Join(names, ",")
+	}
+	if got := format(w.UnusedOutputs(false)); got != "fetch.log" {
+		t.Errorf("UnusedOutputs(false) = %s, want fetch.log", got)
+	}
+	if got := format(w.UnusedOutputs(true)); got != "fetch.log,analyze.stats,notify.receipt" {
+		t.Errorf("UnusedOutputs(true) = %s", got)
+	}
+}
+
+func TestWorkflowDuplicateStepNames(t *testing.T) {
+	w := &Workflow{Steps: []Step{
+		{Name: "a", Agent: "x"},
+		{Name: "b", Agent: "x"},
+		{Name: "a", Agent: "y"},
+		{Agent: "x"},
+		{Agent: "x"},
+		{Name: "b", Agent: "x"},
+		{Name: "a", Agent: "x"},
+	}}
+	if got := strings.Join(w.DuplicateStepNames(), ","); got != "a,b" {
+		t.Errorf("DuplicateStepNames() = %s, want a,b", got)
+	}
+
+	err := w.Validate()
+	want := `steps[2].name: duplicate step name "a"; steps[3].name: is required; steps[4].name: is required; steps[5].name: duplicate step name "b"; steps[6].name: duplicate step name "a"`
+	if err == nil || err.Error() != want {
+		t.Errorf("Validate() = %v, want %q", err, want)
+	}
+}
+
+func TestWorkflowValidateStepAgent(t *testing.T) {
+	w := &Workflow{Type: WorkflowSequential, Steps: []Step{{Name: "fetch", Agent: "x"}, {Name: "draft"}}}
+	err := w.Validate()
+	if err == nil || err.Error() != `steps[1].agent: step "draft" has no agent` {
+		t.Errorf("Validate() = %v", err)
+	}
+
+	team := NewTeam("t", "1.0.0").WithAgents("x").WithWorkflow(w)
+	if err := team.Validate(); err == nil || err.Error() != `workflow.steps[1].agent: step "draft" has no agent` {
+		t.Errorf("Team.Validate() = %v", err)
+	}
+}
+
+func TestWorkflowValidateParallelIndependence(t *testing.T) {
+	w := &Workflow{Type: WorkflowParallel, Steps: []Step{
+		{Name: "a", Agent: "x"},
+		{Name: "b", Agent: "x", DependsOn: []string{"a", "c"}},
+		{Name: "c", Agent: "x"},
+	}}
+	err := w.ValidateParallelIndependence()
+	want := "steps[1]: depends on a, c, but parallel steps must be independent; use type dag for dependencies"
+	if err == nil || err.Error() != want {
+		t.Errorf("ValidateParallelIndependence() = %v, want %q", err, want)
+	}
+
+	w.Type = WorkflowDAG
+	if err := w.ValidateParallelIndependence(); err != nil {
+		t.Errorf("ValidateParallelIndependence() = %v for dag workflow", err)
+	}
+}
+
+func TestWorkflowBudget(t *testing.T) {
+	w := &Workflow{
+		Steps:  []Step{{Name: "a", Agent: "x"}, {Name: "b", Agent: "x"}},
+		Budget: &WorkflowBudget{MaxDurationSeconds: -1, MaxCostUSD: -0.5},
+	}
+	err := w.Validate()
+	want := "budget.max_duration_seconds: must be non-negative, got -1; budget.max_cost_usd: must be non-negative, got -0.5"
+	if err == nil || err.Error() != want {
+		t.Errorf("Validate() = %v, want %q", err, want)
+	}
+	if w.ExceedsStepBudget() {
+		t.Error("ExceedsStepBudget() = true with no step limit")
+	}
+
+	w.Budget = &WorkflowBudget{MaxSteps: 1}
+	if !w.ExceedsStepBudget() {
+		t.Error("ExceedsStepBudget() = false for 2 steps with MaxSteps 1")
+	}
+	w.Budget.MaxSteps = 2
+	if w.ExceedsStepBudget() {
+		t.Error("ExceedsStepBudget() = true for 2 steps with MaxSteps 2")
+	}
+	if (&Workflow{}).ExceedsStepBudget() {
+		t.Error("ExceedsStepBudget() = true with no budget")
+	}
+}
+
+func TestGroupConsecutiveByAgent(t *testing.T) {
+	w := &Workflow{
+		Steps: []Step{
+			{Name: "fetch", Agent: "research"},
+			{Name: "outline", Agent: "writer", DependsOn: []string{"fetch"}},
+			{Name: "summarize", Agent: "research", DependsOn: []string{"fetch"}},
+			{Name: "draft", Agent: "writer", DependsOn: []string{"outline", "summarize"}},
+			{Name: "polish", Agent: "writer", DependsOn: []string{"draft"}},
+		},
+	}
+
+	groups := w.GroupConsecutiveByAgent()
+	var got []string
+	for _, g := range groups {
+		var names []string
+		for _, s := range g {
+			names = append(names, s.Name)
+		}
+		got = append(got, g[0].Agent+":"+strings.Join(names, ","))
+	}
+	want := "research:fetch,summarize|writer:outline,draft,polish"
+	if strings.Join(got, "|") != want {
+		t.Errorf("groups = %s, want %s", strings.Join(got, "|"), want)
+	}
+
+	cyclic := &Workflow{Steps: []Step{
+		{Name: "a", Agent: "x", DependsOn: []string{"b"}},
+		{Name: "b", Agent: "x", DependsOn: []string{"a"}},
+	}}
+	if groups := cyclic.GroupConsecutiveByAgent(); groups != nil {
+		t.Errorf("cyclic workflow groups = %v, want nil", groups)
+	}
+}
+
+func TestValidateSequentialOrder(t *testing.T) {
+	w := &Workflow{
+		Type: WorkflowSequential,
+		Steps: []Step{
+			{Name: "build", Agent: "a", DependsOn: []string{"test"}},
+			{Name: "test", Agent: "a"},
+			{Name: "release", Agent: "a", DependsOn: []string{"build", "ghost", "release"}},
+		},
+	}
+
+	err := w.ValidateSequentialOrder()
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 3 {
+		t.Fatalf("ValidateSequentialOrder() = %v, want 3 errors", err)
+	}
+	if errs[0].Error() != "steps[0]: depends on test, which runs later in a sequential workflow" {
+		t.Errorf("errs[0] = %q", errs[0].Error())
+	}
+	if errs[1].Error() != "steps[2]: depends on unknown step ghost" {
+		t.Errorf("errs[1] = %q", errs[1].Error())
+	}
+
+	w.Steps[0], w.Steps[1] = w.Steps[1], w.Steps[0]
+	w.Steps[2].DependsOn = []string{"build", "test"}
+	if err := w.ValidateSequentialOrder(); err != nil {
+		t.Errorf("ValidateSequentialOrder() = %v, want nil", err)
+	}
+
+	dag := &Workflow{Type: WorkflowDAG, Steps: []Step{{Name: "a", Agent: "x", DependsOn: []string{"b"}}, {Name: "b", Agent: "x"}}}
+	if err := dag.ValidateSequentialOrder(); err != nil {
+		t.Errorf("non-sequential workflow should not be checked, got %v", err)
+	}
+}
+
+func TestWorkflowIsDeterministic(t *testing.T) {
+	yes, no := true, false
+	w := &Workflow{Steps: []Step{
+		{Name: "parse", Agent: "a", Deterministic: &yes},
+		{Name: "format", Agent: "a", Deterministic: &yes},
+	}}
+	if !w.IsDeterministic() {
+		t.Error("IsDeterministic() = false, want true")
+	}
+
+	w.Steps = append(w.Steps, Step{Name: "search", Agent: "a", Deterministic: &no})
+	if w.IsDeterministic() {
+		t.Error("IsDeterministic() = true with a non-deterministic step")
+	}
+
+	w.Steps[2].Deterministic = nil
+	if w.IsDeterministic() {
+		t.Error("IsDeterministic() = true with an unmarked step")
+	}
+}
+
+func TestDetectDataDeadlocks(t *testing.T) {
+	w := &Workflow{
+		Type: WorkflowDAG,
+		Steps: []Step{
+			{Name: "draft", Agent: "writer", Inputs: []Port{{Name: "notes", From: "review.notes"}}, Outputs: []Port{{Name: "text"}}},
+			{Name: "review", Agent: "editor", Inputs: []Port{{Name: "text", From: "draft.text"}}, Outputs: []Port{{Name: "notes"}}},
+			{Name: "publish", Agent: "writer", DependsOn: []string{"review"}},
+			{Name: "a", Agent: "x", DependsOn: []string{"b"}},
+			{Name: "b", Agent: "x", Inputs: []Port{{Name: "in", From: "a.out"}, {Name: "ghost", From: "missing.out"}}},
+		},
+	}
+
+	// DependsOn alone has no cycle.
+	if _, err := w.TopologicalOrder(); err != nil {
+		t.Fatalf("TopologicalOrder failed: %v", err)
+	}
+
+	got := w.DetectDataDeadlocks()
+	want := []string{"draft -> review -> draft", "a -> b -> a"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("DetectDataDeadlocks() = %q, want %q", got, want)
+	}
+
+	// The search enters the cycle at c, from a, but it is reported from b.
+	late := &Workflow{Steps: []Step{
+		{Name: "a", Agent: "x", DependsOn: []string{"c"}},
+		{Name: "b", Agent: "x", DependsOn: []string{"c"}},
+		{Name: "c", Agent: "x", DependsOn: []string{"b"}},
+	}}
+	if got := late.DetectDataDeadlocks(); strings.Join(got, "|") != "b -> c -> b" {
+		t.Errorf("DetectDataDeadlocks() = %q, want [b -> c -> b]", got)
+	}
+
+	acyclic := &Workflow{Steps: []Step{
+		{Name: "fetch", Agent: "x", Outputs: []Port{{Name: "data"}}},
+		{Name: "parse", Agent: "x", DependsOn: []string{"fetch"}, Inputs: []Port{{Name: "data", From: "fetch.data"}}},
+	}}
+	if got := acyclic.DetectDataDeadlocks(); got != nil {
+		t.Errorf("DetectDataDeadlocks() = %q, want nil", got)
+	}
+}
+
+func TestWorkflowAppend(t *testing.T) {
+	base := &Workflow{
+		Type:   WorkflowDAG,
+		Budget: &WorkflowBudget{MaxSteps: 10},
+		Steps: []Step{
+			{Name: "draft", Agent: "writer", Outputs: []Port{{Name: "text"}}},
+			{Name: "review", Agent: "editor", DependsOn: []string{"draft"}},
+		},
+	}
+	fragment := &Workflow{
+		Steps: []Step{
+			{Name: "review", Agent: "reviewer", Inputs: []Port{{Name: "text", From: "draft.text"}}, Outputs: []Port{{Name: "notes"}}},
+			{Name: "approve", Agent: "lead", DependsOn: []string{"review"}, Inputs: []Port{{Name: "notes", From: "review.notes"}}},
+		},
+	}
+
+	merged, err := base.Append(fragment, []string{"review"})
+	if err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if len(merged.Steps) != 4 || merged.Type != WorkflowDAG || merged.Budget == nil || merged.Budget.MaxSteps != 10 {
+		t.Fatalf("merged = %+v", merged)
+	}
+
+	sub := merged.Steps[2]
+	if sub.Name != "sub-review" || strings.Join(sub.DependsOn, ",") != "review" {
+		t.Errorf("appended root = %+v", sub)
+	}
+	if sub.Inputs[0].From != "draft.text" {
+		t.Errorf("external From rewritten: %q", sub.Inputs[0].From)
+	}
+	approve := merged.Steps[3]
+	if strings.Join(approve.DependsOn, ",") != "sub-review" || approve.Inputs[0].From != "sub-review.notes" {
+		t.Errorf("approve = %+v", approve)
+	}
+
+	sub.Outputs[0].Name = "changed"
+	if fragment.Steps[0].Outputs[0].Name != "notes" {
+		t.Error("appended step shares its outputs with the original")
+	}
+	if fragment.Steps[1].DependsOn[0] != "review" || fragment.Steps[1].Inputs[0].From != "review.notes" || len(base.Steps) != 2 {
+		t.Error("Append modified its inputs")
+	}
+	if _, err := merged.TopologicalOrder(); err != nil {
+		t.Errorf("merged workflow is not acyclic: %v", err)
+	}
+}
+
+func TestWorkflowAppendErrors(t *testing.T) {
+	base := &Workflow{Steps: []Step{{Name: "a", Agent: "x"}, {Name: "sub-a", Agent: "x"}}}
+
+	if _, err := base.Append(&Workflow{Steps: []Step{{Name: "a", Agent: "y"}}}, nil); err == nil ||
+		!strings.Contains(err.Error(), "step a collides even as sub-a") {
+		t.Errorf("Append error = %v, want collision error", err)
+	}
+	if _, err := base.Append(&Workflow{}, []string{"ghost"}); err == nil {
+		t.Error("Append should fail for unknown connectAfter step")
+	}
+	if _, err := base.Append(nil, nil); err == nil {
+		t.Error("Append should fail for a nil workflow")
+	}
+}
+
+func TestWorkflowStages(t *testing.T) {
+	w := &Workflow{Steps: []Step{
+		{Name: "report", Agent: "a", DependsOn: []string{"analyze", "fetch"}},
+		{Name: "fetch", Agent: "a"},
+		{Name: "analyze", Agent: "a", DependsOn: []string{"fetch"}},
+		{Name: "notify", Agent: "a"},
+	}}
+
+	stages, err := w.Stages()
+	if err != nil {
+		t.Fatalf("Stages failed: %v", err)
+	}
+	var got []string
+	for _, stage := range stages {
+		var names []string
+		for _, s := range stage {
+			names = append(names, s.Name)
+		}
+		got = append(got, strings.Join(names, ","))
+	}
+	if strings.Join(got, "|") != "fetch,notify|analyze|report" {
+		t.Errorf("Stages() = %v, want [fetch,notify analyze report]", got)
+	}
+}
+
+func TestDetectOutputConflicts(t *testing.T) {
+	file := func(name string) Port { return Port{Name: name, Type: PortTypeFile} }
+	w := &Workflow{
+		Type: WorkflowParallel,
+		Steps: []Step{
+			{Name: "lint", Agent: "a", Outputs: []Port{file("report.json"), {Name: "summary"}}},
+			{Name: "test", Agent: "b", Outputs: []Port{file("report.json"), {Name: "summary"}}},
+			{Name: "merge", Agent: "c", DependsOn: []string{"lint", "test"}, Outputs: []Port{file("report.json")}},
+		},
+	}
+
+	conflicts := w.DetectOutputConflicts()
+	if len(conflicts) != 1 {
+		t.Fatalf("conflicts = %+v, want 1", conflicts)
+	}
+	c := conflicts[0]
+	if c.Stage != 0 || c.Output != "report.json" || strings.Join(c.Steps, ",") != "lint,test" {
+		t.Errorf("conflict = %+v", c)
+	}
+
+	w.Type = WorkflowSequential
+	if conflicts := w.DetectOutputConflicts(); conflicts != nil {
+		t.Errorf("sequential workflow conflicts = %+v, want nil", conflicts)
+	}
+}
+
+func TestWorkflowTree(t *testing.T) {
+	w := &Workflow{Steps: []Step{
+		{Name: "fetch", Agent: "x"},
+		{Name: "analyze", Agent: "x", DependsOn: []string{"fetch"}},
+		{Name: "lint", Agent: "x", DependsOn: []string{"fetch"}},
+		{Name: "report", Agent: "x", DependsOn: []string{"analyze", "lint"}},
+		{Name: "standalone", Agent: "x"},
+		{Name: "loop-a", Agent: "x", DependsOn: []string{"loop-b"}},
+		{Name: "loop-b", Agent: "x", DependsOn: []string{"loop-a"}},
+	}}
+
+	want := `fetch
+├── analyze
+│   └── report
+└── lint
+    └── report (see above)
+standalone
+loop-a
+└── loop-b
+    └── loop-a (see above)
+`
+	if got := w.Tree(); got != want {
+		t.Errorf("Tree() =\n%s\nwant\n%s", got, want)
+	}
+	if got := (&Workflow{}).Tree(); got != "" {
+		t.Errorf("Tree() of empty workflow = %q", got)
+	}
+}
+
+func TestWorkflowValidatePortTypes(t *testing.T) {
+	w := &Workflow{Steps: []Step{
+		{Name: "a", Agent: "x", Outputs: []Port{{Name: "count", Type: PortTypeNumber}, {Name: "notes"}}},
+		{Name: "b", Agent: "x", DependsOn: []string{"a"}, Inputs: []Port{
+			{Name: "n", Type: PortTypeString, From: "a.count"},
+			{Name: "m", Type: PortTypeString, From: "a.notes"},
+			{Name: "k", Type: PortTypeNumber, From: "a.count"},
+		}},
+	}}
+	err := w.ValidatePortTypes()
+	want := "steps[1].inputs[0]: type string does not match type number of a.count"
+	if err == nil || err.Error() != want {
+		t.Errorf("ValidatePortTypes() = %v, want %q", err, want)
+	}
+}
+
+func TestWorkflowValidateAll(t *testing.T) {
+	w := &Workflow{Steps: []Step{
+		{Name: "a", Agent: "x", Outputs: []Port{{Name: "count", Type: PortTypeNumber}}},
+		{Name: "c", Agent: "x"},
+		{Name: "c", Agent: "x"},
+		{Name: "b", Agent: "x", DependsOn: []string{"a", "missing"}, Inputs: []Port{
+			{Name: "n", Type: PortTypeString, From: "a.count"},
+		}},
+	}}
+	err := w.ValidateAll()
+	if err == nil {
+		t.Fatal("ValidateAll() = nil")
+	}
+	for _, want := range []string{
+		`steps[2].name: duplicate step name "c"`,
+		"steps[3].depends_on[1]: unknown step missing",
+		"steps[3].inputs[0]: type string does not match type number of a.count",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("ValidateAll() = %v, missing %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "cycle") {
+		t.Errorf("ValidateAll() = %v, cycle check should be skipped", err)
+	}
+
+	cyclic := &Workflow{Steps: []Step{
+		{Name: "a", Agent: "x", DependsOn: []string{"b"}},
+		{Name: "b", Agent: "x", DependsOn: []string{"a"}},
+	}}
+	err = cyclic.ValidateAll()
+	if want := "steps: workflow has a dependency cycle among steps: a, b"; err == nil || err.Error() != want {
+		t.Errorf("ValidateAll() = %v, want %q", err, want)
+	}
+
+	ok := &Workflow{Steps: []Step{
+		{Name: "a", Agent: "x", Outputs: []Port{{Name: "count", Type: PortTypeNumber}}},
+		{Name: "b", Agent: "x", DependsOn: []string{"a"}, Inputs: []Port{{Name: "n", Type: PortTypeNumber, From: "a.count"}}},
+	}}
+	if err := ok.ValidateAll(); err != nil {
+		t.Errorf("ValidateAll() = %v, want nil", err)
+	}
+}
+
+func TestWorkflowSubgraph(t *testing.T) {
+	w := &Workflow{
+		Type: WorkflowDAG,
+		Steps: []Step{
+			{Name: "fetch", Agent: "a", Outputs: []Port{{Name: "data"}}},
+			{Name: "analyze", Agent: "b", DependsOn: []string{"fetch"}, Inputs: []Port{{Name: "data", From: "fetch.data"}}, Outputs: []Port{{Name: "stats"}}},
+			{Name: "report", Agent: "c", DependsOn: []string{"analyze", "fetch"}, Inputs: []Port{{Name: "stats", From: "analyze.stats"}}},
+		},
+	}
+
+	if _, err := w.Subgraph([]string{"report", "analyze"}, false); err == nil ||
+		!strings.Contains(err.Error(), "depends on fetch, which is not in the subgraph") {
+		t.Errorf("Subgraph(dropExternal=false) error = %v", err)
+	}
+	if _, err := w.Subgraph([]string{"ghost"}, true); err == nil {
+		t.Error("Subgraph should fail for an unknown step")
+	}
+
+	sub, err := w.Subgraph([]string{"report", "analyze"}, true)
+	if err != nil {
+		t.Fatalf("Subgraph failed: %v", err)
+	}
+	if sub.Type != WorkflowDAG || len(sub.Steps) != 2 || sub.Steps[0].Name != "analyze" || sub.Steps[1].Name != "report" {
+		t.Fatalf("Subgraph() = %+v", sub)
+	}
+	if len(sub.Steps[0].DependsOn) != 0 || sub.Steps[0].Inputs[0].From != "" {
+		t.Errorf("analyze = %+v, want external input and no dependencies", sub.Steps[0])
+	}
+	if strings.Join(sub.Steps[1].DependsOn, ",") != "analyze" || sub.Steps[1].Inputs[0].From != "analyze.stats" {
+		t.Errorf("report = %+v", sub.Steps[1])
+	}
+	if err := sub.ValidateAll(); err != nil {
+		t.Errorf("subgraph is not valid: %v", err)
+	}
+	if w.Steps[1].Inputs[0].From != "fetch.data" || len(w.Steps[2].DependsOn) != 2 {
+		t.Error("Subgraph modified the workflow")
+	}
+}
+
+func TestWorkflowUnreachableSteps(t *testing.T) {
+	w := &Workflow{Steps: []Step{
+		{Name: "start", Agent: "x"},
+		{Name: "next", Agent: "x", DependsOn: []string{"start"}},
+		{Name: "broken", Agent: "x", DependsOn: []string{"start", "ghost"}},
+		{Name: "after-broken", Agent: "x", DependsOn: []string{"broken"}},
+		{Name: "loop-a", Agent: "x", DependsOn: []string{"loop-b"}},
+		{Name: "loop-b", Agent: "x", DependsOn: []string{"loop-a", "start"}},
+		{Name: "end", Agent: "x", DependsOn: []string{"next", "start"}},
+	}}
+	got, err := w.UnreachableSteps()
+	if err != nil {
+		t.Fatalf("UnreachableSteps failed: %v", err)
+	}
+	if want := "broken,after-broken,loop-a,loop-b"; strings.Join(got, ",") != want {
+		t.Errorf("UnreachableSteps() = %v, want %s", got, want)
+	}
+
+	dup := &Workflow{Steps: []Step{{Name: "a", Agent: "x"}, {Name: "a", Agent: "x"}}}
+	if _, err := dup.UnreachableSteps(); err == nil {
+		t.Error("UnreachableSteps should fail for duplicate step names")
+	}
+}
@@ -49,13 +49,10 @@ func run() error {
 		return fmt.Errorf("generating team schema: %w", err)
 	}
 
-	// Generate Deployment schema
-	if err := generateSchema(
-		&multiagentspec.Deployment{},
+	// Generate Deployment schema (shared with the SDK's drift test)
+	if err := writeSchema(
+		multiagentspec.DeploymentSchema(),
 		filepath.Join(outputDir, "deployment", "deployment.schema.json"),
-		"Multi-Agent Spec - Deployment Definition",
-		"Schema for defining deployment targets for multi-agent systems",
-		"https://raw.githubusercontent.com/agentplexus/multi-agent-spec/main/schema/deployment/deployment.schema.json",
 	); err != nil {
 		return fmt.Errorf("generating deployment schema: %w", err)
 	}
@@ -95,6 +92,10 @@ func generateSchema(v interface{}, outputPath, title, description, id string) er
 		return fmt.Errorf("marshaling schema: %w", err)
 	}
 
+	return writeSchema(data, outputPath)
+}
+
+func writeSchema(data []byte, outputPath string) error {
 	// Ensure directory exists
 	dir := filepath.Dir(outputPath)
 	if err := os.MkdirAll(dir, 0o755); err != nil {